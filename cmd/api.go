@@ -0,0 +1,337 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer"
+	"github.com/icon-project/centralized-relay/relayer/metrics"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"go.uber.org/zap"
+)
+
+// defaultAPITokenEnv holds the admin api token when neither api-token-file nor api-token-env is set
+const defaultAPITokenEnv = "RELAY_API_TOKEN"
+
+// apiServer serves the metrics and the admin endpoints of a running relayer on api-listen-addr
+type apiServer struct {
+	log *zap.Logger
+	mux *http.ServeMux
+	// token is the bearer token of the admin requests which change the relayer,
+	// they are refused when it is empty
+	token string
+}
+
+func newAPIServer(log *zap.Logger, token string) *apiServer {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	return &apiServer{log: log, mux: mux, token: token}
+}
+
+// handleAdmin registers an admin endpoint, every method but GET needs the bearer token
+func (s *apiServer) handleAdmin(path string, handler http.HandlerFunc) {
+	s.mux.HandleFunc(path, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			if s.token == "" {
+				writeJSON(w, http.StatusForbidden, map[string]string{
+					"error": fmt.Sprintf("the admin api is read only, set api-token-file, api-token-env or %s", defaultAPITokenEnv),
+				})
+				return
+			}
+			if !s.authorized(req) {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="relayer"`)
+				writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "missing or invalid admin api token"})
+				return
+			}
+		}
+		handler(w, req)
+	})
+}
+
+func (s *apiServer) authorized(req *http.Request) bool {
+	token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1
+}
+
+// apiToken returns the bearer token of the admin api, empty when none is configured
+func (g *GlobalConfig) apiToken() (string, error) {
+	switch {
+	case g.APITokenFile != "":
+		b, err := os.ReadFile(g.APITokenFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read api token file: %w", err)
+		}
+		return strings.TrimSpace(string(b)), nil
+	case g.APITokenEnv != "":
+		token := os.Getenv(g.APITokenEnv)
+		if token == "" {
+			return "", fmt.Errorf("api token environment variable %s is empty", g.APITokenEnv)
+		}
+		return token, nil
+	}
+	return os.Getenv(defaultAPITokenEnv), nil
+}
+
+// handleReload reloads the chain config on POST /reload
+func (s *apiServer) handleReload(reloader *configReloader) {
+	s.handleAdmin("/reload", func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		result, err := reloader.Reload()
+		if err != nil {
+			s.log.Error("failed to reload config", zap.String("trigger", "api"), zap.Error(err))
+			writeJSON(w, http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+			return
+		}
+		s.log.Info("config reloaded", zap.String("trigger", "api"), zap.Any("result", result))
+		writeJSON(w, http.StatusOK, result)
+	})
+}
+
+// handlePauses lists the pauses on GET /pauses, pauses on POST /pauses and resumes on DELETE /pauses?src=&dst=
+func (s *apiServer) handlePauses(rly *relayer.Relayer) {
+	s.handleAdmin("/pauses", func(w http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, rly.Pauses())
+		case http.MethodPost:
+			var pause types.Pause
+			if err := json.NewDecoder(req.Body).Decode(&pause); err != nil {
+				writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+				return
+			}
+			if err := rly.Pause(pause); err != nil {
+				writeJSON(w, http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+				return
+			}
+			writeJSON(w, http.StatusOK, rly.Pauses())
+		case http.MethodDelete:
+			query := req.URL.Query()
+			if err := rly.Resume(query.Get("src"), query.Get("dst")); err != nil {
+				writeJSON(w, http.StatusNotFound, map[string]string{"error": err.Error()})
+				return
+			}
+			writeJSON(w, http.StatusOK, rly.Pauses())
+		default:
+			w.Header().Set("Allow", "GET, POST, DELETE")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+// handleStatus serves the status of the chains on GET /status?nid=, all the chains without nid
+func (s *apiServer) handleStatus(rly *relayer.Relayer) {
+	s.handleAdmin("/status", func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		statuses, err := rly.ChainStatus(req.Context(), req.URL.Query()["nid"]...)
+		if err != nil {
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, statuses)
+	})
+}
+
+// handleAudit audits the source chains on POST /audit with the relayer.AuditOptions as body
+func (s *apiServer) handleAudit(rly *relayer.Relayer) {
+	s.handleAdmin("/audit", func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var opts relayer.AuditOptions
+		if err := json.NewDecoder(req.Body).Decode(&opts); err != nil && !errors.Is(err, io.EOF) {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+		reports, err := rly.Audit(req.Context(), opts)
+		if err != nil {
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, reports)
+	})
+}
+
+// handleRescan scans the blocks of a chain again on POST /rescan with the relayer.RescanOptions as body
+func (s *apiServer) handleRescan(rly *relayer.Relayer) {
+	s.handleAdmin("/rescan", func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var opts relayer.RescanOptions
+		if err := json.NewDecoder(req.Body).Decode(&opts); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+		report, err := rly.Rescan(req.Context(), opts)
+		if err != nil {
+			writeJSON(w, http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, report)
+	})
+}
+
+// handleInject injects the messages of a source transaction on POST /inject with the relayer.InjectOptions as body
+func (s *apiServer) handleInject(rly *relayer.Relayer) {
+	s.handleAdmin("/inject", func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var opts relayer.InjectOptions
+		if err := json.NewDecoder(req.Body).Decode(&opts); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+		injected, err := rly.Inject(req.Context(), opts)
+		if err != nil {
+			writeJSON(w, http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+			return
+		}
+		s.log.Info("injected source transaction", zap.String("chain", opts.Chain), zap.String("tx-hash", opts.TxHash), zap.Any("messages", injected))
+		writeJSON(w, http.StatusOK, injected)
+	})
+}
+
+// handleRelease releases a quarantined message on POST /quarantine/release with the relayer.ReleaseOptions as body
+func (s *apiServer) handleRelease(rly *relayer.Relayer) {
+	s.handleAdmin("/quarantine/release", func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var opts relayer.ReleaseOptions
+		if err := json.NewDecoder(req.Body).Decode(&opts); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+		released, err := rly.ReleaseQuarantined(req.Context(), opts)
+		if err != nil {
+			writeJSON(w, http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+			return
+		}
+		s.log.Info("released quarantined message", zap.String("chain", opts.Chain), zap.Uint64("sn", opts.Sn), zap.String("status", string(released.Status)))
+		writeJSON(w, http.StatusOK, released)
+	})
+}
+
+// Serve listens on addr until ctx is done
+func (s *apiServer) Serve(ctx context.Context, addr string) {
+	srv := &http.Server{Addr: addr, Handler: s.mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		srv.Close()
+	}()
+
+	s.log.Info("serving api", zap.String("addr", addr))
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		s.log.Error("api server stopped", zap.Error(err))
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// defaultAPITimeout bounds the calls to the api of the running relayer
+const defaultAPITimeout = 30 * time.Second
+
+// callAPI calls the api of the running relayer and decodes the json response into result
+func (a *appState) callAPI(method, path string, query url.Values, body []byte, result any) error {
+	return a.callAPITimeout(defaultAPITimeout, method, path, query, body, result)
+}
+
+// defaultScanTimeout bounds the api calls which go through block or sn ranges
+const defaultScanTimeout = 10 * time.Minute
+
+// callAPITimeout is callAPI for the calls which can take longer than defaultAPITimeout
+func (a *appState) callAPITimeout(timeout time.Duration, method, path string, query url.Values, body []byte, result any) error {
+	if a.config == nil || a.config.Global == nil || a.config.Global.APIListenPort == "" {
+		return &net.OpError{Op: "dial", Err: errors.New("api-listen-addr is not configured")}
+	}
+	host, port, err := net.SplitHostPort(a.config.Global.APIListenPort)
+	if err != nil {
+		return err
+	}
+	if host == "" {
+		host = "127.0.0.1"
+	}
+	u := url.URL{Scheme: "http", Host: net.JoinHostPort(host, port), Path: path, RawQuery: query.Encode()}
+	req, err := http.NewRequest(method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	token, err := a.config.Global.apiToken()
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Error != "" {
+			return errors.New(apiErr.Error)
+		}
+		return fmt.Errorf("relayer api returned %s", resp.Status)
+	}
+	return json.Unmarshal(data, result)
+}
+
+// apiUnreachable is true when no relayer api listens, the commands then work on the database
+func apiUnreachable(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr)
+}
+
+// offlineRelayer opens the database for the commands run while no relayer is running
+func (a *appState) offlineRelayer() (*relayer.Relayer, error) {
+	db, err := a.openDB()
+	if err != nil {
+		return nil, fmt.Errorf("relayer api is not reachable and the database cannot be opened: %w", err)
+	}
+	a.db = db
+	state := NewDBState()
+	return state.GetRelayer(a)
+}
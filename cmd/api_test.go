@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestAdminAuth(t *testing.T) {
+	serve := func(token, method, path, auth string) int {
+		s := newAPIServer(zap.NewNop(), token)
+		s.handleAdmin("/reload", func(w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+		req := httptest.NewRequest(method, path, nil)
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		rec := httptest.NewRecorder()
+		s.mux.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serve("secret", http.MethodPost, "/reload", "Bearer secret"))
+	assert.Equal(t, http.StatusUnauthorized, serve("secret", http.MethodPost, "/reload", ""))
+	assert.Equal(t, http.StatusUnauthorized, serve("secret", http.MethodPost, "/reload", "Bearer other"))
+	assert.Equal(t, http.StatusUnauthorized, serve("secret", http.MethodPost, "/reload", "secret"))
+	// without a token the admin api is read only
+	assert.Equal(t, http.StatusForbidden, serve("", http.MethodPost, "/reload", "Bearer "))
+	assert.Equal(t, http.StatusOK, serve("", http.MethodGet, "/reload", ""))
+	// the metrics stay open
+	assert.Equal(t, http.StatusOK, serve("secret", http.MethodGet, "/metrics", ""))
+}
@@ -8,6 +8,8 @@ import (
 
 	"github.com/gofrs/flock"
 	"github.com/icon-project/centralized-relay/relayer/lvldb"
+	"github.com/icon-project/centralized-relay/relayer/memdb"
+	"github.com/icon-project/centralized-relay/relayer/store"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
 	"gopkg.in/yaml.v3"
@@ -25,8 +27,38 @@ type appState struct {
 	configPath string
 	dbPath     string
 	debug      bool
+	dbBackend  string
 	config     *Config
-	db         *lvldb.LVLDB
+	db         store.Store
+}
+
+// database backends selectable with --db-backend
+const (
+	dbBackendLevelDB = "leveldb"
+	dbBackendMemory  = "memory"
+)
+
+// openDB opens the database of the configured backend,
+// the memory backend keeps nothing once the command exits and is meant for dry runs,
+// its memory grows with the live data as the space of deleted entries is reclaimed
+func (a *appState) openDB() (store.Store, error) {
+	switch a.dbBackend {
+	case dbBackendLevelDB, "":
+		return lvldb.NewLvlDB(a.dbPath, false)
+	case dbBackendMemory:
+		return memdb.NewMemDB(), nil
+	default:
+		return nil, fmt.Errorf("unknown db backend %q, must be one of %s or %s", a.dbBackend, dbBackendLevelDB, dbBackendMemory)
+	}
+}
+
+// levelDB returns the leveldb database, operations on database files are not available for other backends
+func (a *appState) levelDB() (*lvldb.LVLDB, error) {
+	db, ok := a.db.(*lvldb.LVLDB)
+	if !ok {
+		return nil, fmt.Errorf("operation requires the %s db backend", dbBackendLevelDB)
+	}
+	return db, nil
 }
 
 // loadConfigFile reads config file into a.Config if file is present.
@@ -36,17 +68,10 @@ func (a *appState) loadConfigFile(ctx context.Context) error {
 		return nil
 	}
 
-	// read the config file bytes
-	file, err := os.ReadFile(a.configPath)
+	// read the config file with the ${ENV_VAR} and file:// references resolved
+	cfgWrapper, refs, err := readConfigFile(a.configPath)
 	if err != nil {
-		return fmt.Errorf("error reading file: %w", err)
-	}
-
-	// unmarshall them into the wrapper struct
-	cfgWrapper := &ConfigInputWrapper{}
-	err = yaml.Unmarshal(file, cfgWrapper)
-	if err != nil {
-		return fmt.Errorf("error unmarshalling config: %w", err)
+		return err
 	}
 
 	// retrieve the runtime configuration from the disk configuration.
@@ -61,12 +86,56 @@ func (a *appState) loadConfigFile(ctx context.Context) error {
 	}
 
 	// save runtime configuration in app state
+	newCfg.refs = refs
 	a.config = newCfg
 
 	return nil
 }
 
+// dataStore returns the store used by the relayer,
+// the database is wrapped with encryption when an encryption key is configured
+func (a *appState) dataStore() (store.Store, error) {
+	key, hashKeys, err := a.encryptionKey()
+	if err != nil || key == nil {
+		return a.db, err
+	}
+	return store.NewEncryptedStore(a.db, key, hashKeys)
+}
+
+// encryptionKey returns the configured database encryption key, nil if encryption is disabled
+func (a *appState) encryptionKey() ([]byte, bool, error) {
+	if a.config == nil || a.config.Global == nil {
+		return nil, false, nil
+	}
+	global := a.config.Global
+	key, err := store.LoadEncryptionKey(global.DBEncryptionKeyFile, global.DBEncryptionKeyEnv)
+	return key, global.DBHashKeys, err
+}
+
 func (a *appState) performConfigLockingOperation(ctx context.Context, operation func() error) error {
+	return a.withConfigLock(func() error {
+		// load config from file and validate it. don't want to miss
+		// any changes that may have been made while unlocked.
+		if err := a.loadConfigFile(ctx); err != nil {
+			return fmt.Errorf("failed to initialize config from file: %w", err)
+		}
+
+		// perform the operation that requires config flock.
+		if err := operation(); err != nil {
+			return err
+		}
+
+		// validate config after changes have been made.
+		if err := a.config.validateConfig(); err != nil {
+			return fmt.Errorf("error parsing chain config: %w", err)
+		}
+
+		return a.writeConfigFile(a.config.Wrapped(), a.config.refs)
+	})
+}
+
+// withConfigLock runs operation while holding the config file lock
+func (a *appState) withConfigLock(operation func() error) error {
 	lockFilePath := path.Join(a.homePath, "config.lock")
 	fileLock := flock.New(lockFilePath)
 	_, err := fileLock.TryLock()
@@ -80,25 +149,16 @@ func (a *appState) performConfigLockingOperation(ctx context.Context, operation
 			)
 		}
 	}()
+	return operation()
+}
 
-	// load config from file and validate it. don't want to miss
-	// any changes that may have been made while unlocked.
-	if err := a.loadConfigFile(ctx); err != nil {
-		return fmt.Errorf("failed to initialize config from file: %w", err)
-	}
-
-	// perform the operation that requires config flock.
-	if err := operation(); err != nil {
+// writeConfigFile overwrites the config file, keeping the references in place of the resolved secrets
+func (a *appState) writeConfigFile(cfg *ConfigOutputWrapper, refs configRefs) error {
+	node, err := refs.encode(cfg, false)
+	if err != nil {
 		return err
 	}
-
-	// validate config after changes have been made.
-	if err := a.config.validateConfig(); err != nil {
-		return fmt.Errorf("error parsing chain config: %w", err)
-	}
-
-	// marshal the new config
-	out, err := yaml.Marshal(a.config.Wrapped())
+	out, err := yaml.Marshal(node)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer"
+	"github.com/spf13/cobra"
+)
+
+func auditCmd(a *appState) *cobra.Command {
+	opts := relayer.AuditOptions{}
+	var timeout time.Duration
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Find the messages of the source connections which their destinations did not receive",
+		Long: strings.TrimSpace(fmt.Sprintf(`Find the messages of the source connections which their destinations did not receive.
+Every sn emitted by a source connection up to the height processed by the relayer is checked
+on the destinations, the missing messages are generated again from the source chain and
+queued with --requeue. Without --from the audit goes on from the last audited sn.
+A missing message is located by querying the connection sn at past heights, on evm chains
+this needs an rpc serving archive state. A sn which cannot be located or generated holds the
+last audited sn back for %d audits, it is then reported as given up and the audit goes on.
+The audit runs in the running relayer through the api, on the database when no relayer runs.`, relayer.AuditAttempts)),
+		Args: withUsage(cobra.NoArgs),
+		Annotations: map[string]string{
+			annotationSkipDB: "true",
+		},
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s audit
+$ %s audit --src 0x2.icon --from 100 --to 200
+$ %s audit --src 0x13881.mumbai --requeue --json`, appName, appName, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsn, err := cmd.Flags().GetBool(flagJSON)
+			if err != nil {
+				return err
+			}
+			if opts.To > 0 && opts.From > opts.To {
+				return fmt.Errorf("--from %d is after --to %d", opts.From, opts.To)
+			}
+			body, err := json.Marshal(opts)
+			if err != nil {
+				return err
+			}
+
+			var reports []*relayer.AuditReport
+			err = a.callAPITimeout(timeout, http.MethodPost, "/audit", nil, body, &reports)
+			if apiUnreachable(err) {
+				rly, rlyErr := a.offlineRelayer()
+				if rlyErr != nil {
+					return rlyErr
+				}
+				reports, err = rly.Audit(cmd.Context(), opts)
+			}
+			if err != nil {
+				return err
+			}
+
+			if jsn {
+				out, err := json.Marshal(reports)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), string(out))
+				return nil
+			}
+			return printAuditReports(cmd.OutOrStdout(), reports)
+		},
+	}
+	cmd.Flags().StringSliceVar(&opts.Src, "src", nil, "source chain nid to audit, all the chains when not given")
+	cmd.Flags().Uint64Var(&opts.From, "from", 0, "first sn to audit, the sn after the last audit when not given")
+	cmd.Flags().Uint64Var(&opts.To, "to", 0, "last sn to audit, the sn of the height processed by the relayer when not given")
+	cmd.Flags().BoolVar(&opts.Requeue, "requeue", false, "queue the missing messages to be relayed again")
+	cmd.Flags().Uint64Var(&opts.MaxRange, "max-range", relayer.DefaultAuditRange, "maximum number of sn audited per chain without --from")
+	cmd.Flags().DurationVar(&timeout, "timeout", defaultScanTimeout, "maximum wait for the audit of the running relayer")
+	return jsonFlag(a.viper, cmd)
+}
+
+func printAuditReports(out io.Writer, reports []*relayer.AuditReport) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SRC\tFROM\tTO\tRECEIVED\tPENDING\tUNROUTABLE\tGAPS\tERROR")
+	for _, r := range reports {
+		var received uint64
+		for _, n := range r.Received {
+			received += n
+		}
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\t%d\t%s\n",
+			r.Src, r.From, r.To, received, r.Pending, r.Unroutable, len(r.Gaps), r.Error)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	var gaps bool
+	for _, r := range reports {
+		gaps = gaps || len(r.Gaps) > 0
+	}
+	if !gaps {
+		return nil
+	}
+	fmt.Fprintln(out)
+	w = tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SRC\tDST\tSN\tHEIGHT\tREQUEUED\tERROR")
+	for _, r := range reports {
+		for _, gap := range r.Gaps {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%t\t%s\n", gap.Src, gap.Dst, gap.Sn, gap.Height, gap.Requeued, gap.Error)
+		}
+	}
+	return w.Flush()
+}
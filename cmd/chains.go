@@ -4,9 +4,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
+	"text/tabwriter"
 
 	"github.com/icon-project/centralized-relay/relayer"
 	"github.com/spf13/cobra"
@@ -23,6 +27,10 @@ func chainsCmd(a *appState) *cobra.Command {
 
 	cmd.AddCommand(
 		chainsListCmd(a),
+		chainsStatusCmd(a),
+		chainsPauseCmd(a),
+		chainsResumeCmd(a),
+		chainsPausesCmd(a),
 		chainsAddCmd(a),
 		chainsDeleteCmd(a),
 	)
@@ -36,6 +44,9 @@ func chainsListCmd(a *appState) *cobra.Command {
 		Aliases: []string{"l"},
 		Short:   "Returns chain configuration data",
 		Args:    withUsage(cobra.NoArgs),
+		Annotations: map[string]string{
+			annotationSkipDB: "true",
+		},
 		Example: strings.TrimSpace(fmt.Sprintf(`
 $ %s chains list
 $ %s ch l`, appName, appName)),
@@ -85,6 +96,103 @@ $ %s ch l`, appName, appName)),
 	return yamlFlag(a.viper, jsonFlag(a.viper, cmd))
 }
 
+func chainsStatusCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "status [nid...]",
+		Aliases: []string{"st"},
+		Short:   "Query live diagnostics of the configured chains",
+		Long: strings.TrimSpace(`Query live diagnostics of the configured chains.
+The status is taken from the running relayer when its api is reachable, it then includes
+the transactions in flight, sent and failed of every wallet of the chains.`),
+		Args: withUsage(cobra.ArbitraryArgs),
+		Annotations: map[string]string{
+			annotationSkipDB: "true",
+		},
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s chains status
+$ %s chains status 0x2.icon --json`, appName, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsn, err := cmd.Flags().GetBool(flagJSON)
+			if err != nil {
+				return err
+			}
+
+			var statuses []*relayer.ChainStatus
+			err = a.callAPI(http.MethodGet, "/status", url.Values{"nid": args}, nil, &statuses)
+			if apiUnreachable(err) {
+				rly, rlyErr := a.offlineRelayer()
+				if rlyErr != nil {
+					return rlyErr
+				}
+				statuses, err = rly.ChainStatus(cmd.Context(), args...)
+			}
+			if err != nil {
+				return err
+			}
+
+			if jsn {
+				out, err := json.Marshal(statuses)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), string(out))
+				return nil
+			}
+			return printChainStatus(cmd.OutOrStdout(), statuses)
+		},
+	}
+	return jsonFlag(a.viper, cmd)
+}
+
+func printChainStatus(out io.Writer, statuses []*relayer.ChainStatus) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NID\tTYPE\tLATEST\tSTORED\tLAG\tWALLET\tBALANCE\tCONTRACT")
+	for _, s := range statuses {
+		contract := "unreachable"
+		if s.ContractReachable {
+			contract = "ok"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%s\t%s\t%s\n",
+			s.NID, s.Type, s.LatestHeight, s.LastStoredHeight, s.Lag, s.WalletAddress, s.Balance, contract)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	var wallets bool
+	for _, s := range statuses {
+		wallets = wallets || len(s.Wallets) > 0
+	}
+	if wallets {
+		fmt.Fprintln(out)
+		w = tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NID\tWALLET\tBALANCE\tIN-FLIGHT\tSENT\tFAILED\tNONCE")
+		for _, s := range statuses {
+			for _, wallet := range s.Wallets {
+				nonce, balance := "-", wallet.Balance
+				if wallet.Nonce != nil {
+					nonce = fmt.Sprint(*wallet.Nonce)
+				}
+				if wallet.Drained {
+					balance += " (drained)"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%d\t%s\n",
+					s.NID, wallet.Address, balance, wallet.InFlight, wallet.Sent, wallet.Failed, nonce)
+			}
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	for _, s := range statuses {
+		for _, e := range s.Errors {
+			fmt.Fprintf(out, "%s: %s\n", s.NID, e)
+		}
+	}
+	return nil
+}
+
 func chainsAddCmd(a *appState) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "add [chain-name...]",
@@ -92,6 +200,9 @@ func chainsAddCmd(a *appState) *cobra.Command {
 		Short: "Add a new chain to the configuration file by fetching chain metadata from \n" +
 			" passing a file (-f) ",
 		Args: withUsage(cobra.MinimumNArgs(0)),
+		Annotations: map[string]string{
+			annotationSkipDB: "true",
+		},
 		Example: fmt.Sprintf(` $ %s chains add cosmoshub
  $ %s chains add --file chains/ibc0.json ibc0`, appName, appName),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -146,6 +257,11 @@ func addChainFromFile(a *appState, chainName string, file string) error {
 		return err
 	}
 
+	byt, refs, err := interpolateJSON(byt)
+	if err != nil {
+		return err
+	}
+
 	if err = json.Unmarshal(byt, &pcw); err != nil {
 		return err
 	}
@@ -163,6 +279,13 @@ func addChainFromFile(a *appState, chainName string, file string) error {
 		return err
 	}
 
+	// the references are written to the config file in place of the resolved values
+	if a.config.refs == nil {
+		a.config.refs = make(configRefs)
+	}
+	for path, ref := range refs {
+		a.config.refs[joinPath("chains."+chainName, path)] = ref
+	}
 	return nil
 }
 
@@ -172,6 +295,9 @@ func chainsDeleteCmd(a *appState) *cobra.Command {
 		Aliases: []string{"d"},
 		Short:   "Removes chain from config based off chain-id",
 		Args:    withUsage(cobra.ExactArgs(1)),
+		Annotations: map[string]string{
+			annotationSkipDB: "true",
+		},
 		Example: strings.TrimSpace(fmt.Sprintf(`
 $ %s chains delete ibc-0
 $ %s ch d ibc-0`, appName, appName)),
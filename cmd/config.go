@@ -2,17 +2,23 @@ package cmd
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"net"
 	"os"
 	"path"
 	"reflect"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/icon-project/centralized-relay/relayer"
 	"github.com/icon-project/centralized-relay/relayer/chains/evm"
 	"github.com/icon-project/centralized-relay/relayer/chains/icon"
+	"github.com/icon-project/centralized-relay/relayer/lease"
 	"github.com/icon-project/centralized-relay/relayer/provider"
+	"github.com/icon-project/centralized-relay/relayer/wallet"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 	"gopkg.in/yaml.v3"
@@ -28,6 +34,7 @@ func configCmd(a *appState) *cobra.Command {
 	cmd.AddCommand(
 		configShowCmd(a),
 		configInitCmd(a),
+		configValidateCmd(a),
 	)
 	return cmd
 }
@@ -39,6 +46,9 @@ func configShowCmd(a *appState) *cobra.Command {
 		Aliases: []string{"s", "list", "l"},
 		Short:   "Prints current configuration",
 		Args:    withUsage(cobra.NoArgs),
+		Annotations: map[string]string{
+			annotationSkipDB: "true",
+		},
 		Example: strings.TrimSpace(fmt.Sprintf(`
 $ %s config show --home %s
 $ %s cfg list`, appName, defaultHome, appName)),
@@ -64,18 +74,28 @@ $ %s cfg list`, appName, defaultHome, appName)),
 			if err != nil {
 				return err
 			}
-			switch {
-			case yml && jsn:
+			if yml && jsn {
 				return fmt.Errorf("can't pass both --json and --yaml, must pick one")
+			}
+			// secrets are redacted, references are shown as written in the config file
+			node, err := a.config.refs.encode(a.config.Wrapped(), true)
+			if err != nil {
+				return err
+			}
+			switch {
 			case jsn:
-				out, err := json.Marshal(a.config.Wrapped())
+				var redacted any
+				if err := node.Decode(&redacted); err != nil {
+					return err
+				}
+				out, err := json.Marshal(redacted)
 				if err != nil {
 					return err
 				}
 				fmt.Fprintln(cmd.OutOrStdout(), string(out))
 				return nil
 			default:
-				out, err := yaml.Marshal(a.config.Wrapped())
+				out, err := yaml.Marshal(node)
 				if err != nil {
 					return err
 				}
@@ -147,17 +167,223 @@ $ %s cfg i`, appName, defaultHome, appName)),
 	return cmd
 }
 
+// Command for reporting every problem of the configuration file
+func configValidateCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "validate",
+		Aliases: []string{"v"},
+		Short:   "Reports every problem of the configuration file without starting the relayer",
+		Args:    withUsage(cobra.NoArgs),
+		Annotations: map[string]string{
+			annotationSkipConfig: "true",
+		},
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s config validate --home %s
+$ %s cfg v`, appName, defaultHome, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfgWrapper, _, err := readConfigFile(a.configPath)
+			if err != nil {
+				return err
+			}
+
+			problems := append(cfgWrapper.Problems(), cfgWrapper.KeystoreProblems()...)
+			if len(problems) == 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s is valid\n", a.configPath)
+				return nil
+			}
+			for _, problem := range problems {
+				fmt.Fprintf(cmd.OutOrStdout(), "- %v\n", problem)
+			}
+			return fmt.Errorf("found %d problems in %s", len(problems), a.configPath)
+		},
+	}
+	return cmd
+}
+
 // GlobalConfig describes any global relayer settings
 type GlobalConfig struct {
-	APIListenPort  string `yaml:"api-listen-addr" json:"api-listen-addr"`
+	APIListenPort string `yaml:"api-listen-addr" json:"api-listen-addr"`
+	// APITokenFile or APITokenEnv holds the bearer token required by the admin api requests which change the relayer
+	APITokenFile   string `yaml:"api-token-file,omitempty" json:"api-token-file,omitempty"`
+	APITokenEnv    string `yaml:"api-token-env,omitempty" json:"api-token-env,omitempty"`
 	Timeout        string `yaml:"timeout" json:"timeout"`
 	LightCacheSize int    `yaml:"light-cache-size" json:"light-cache-size"`
+	BackupDir      string `yaml:"backup-dir,omitempty" json:"backup-dir,omitempty"`
+	BackupInterval string `yaml:"backup-interval,omitempty" json:"backup-interval,omitempty"`
+	BackupKeep     int    `yaml:"backup-keep,omitempty" json:"backup-keep,omitempty"`
+	// DBEncryptionKeyFile or DBEncryptionKeyEnv holds the hex encoded key used to encrypt the database
+	DBEncryptionKeyFile string `yaml:"db-encryption-key-file,omitempty" json:"db-encryption-key-file,omitempty"`
+	DBEncryptionKeyEnv  string `yaml:"db-encryption-key-env,omitempty" json:"db-encryption-key-env,omitempty"`
+	// DBHashKeys hides the keys of the database too, prefix iteration then only matches whole "-" separated
+	// segments and the keys are no longer iterated in order
+	DBHashKeys     bool                  `yaml:"db-hash-keys,omitempty" json:"db-hash-keys,omitempty"`
+	BalanceMonitor *BalanceMonitorConfig `yaml:"balance-monitor,omitempty" json:"balance-monitor,omitempty"`
+	// ZeroKeysOnShutdown overwrites the decrypted keystores in memory when the relayer stops
+	ZeroKeysOnShutdown bool `yaml:"zero-keys-on-shutdown,omitempty" json:"zero-keys-on-shutdown,omitempty"`
+	// Audit periodically checks that the destinations received every sn of the source connections
+	Audit *AuditConfig `yaml:"audit,omitempty" json:"audit,omitempty"`
+	// HA runs the relayer as one of active/passive instances, only the leader routes messages
+	HA *HAConfig `yaml:"ha,omitempty" json:"ha,omitempty"`
+}
+
+// HAConfig configures the leader election between the relayer instances sharing a lease,
+// only the lease is shared and every instance keeps its own database
+type HAConfig struct {
+	// Backend is the store of the lease, file is the only built in backend
+	Backend string `yaml:"backend,omitempty" json:"backend,omitempty"`
+	// LeaseFile is the lease of the file backend, on storage shared by the instances
+	LeaseFile string `yaml:"lease-file,omitempty" json:"lease-file,omitempty"`
+	// ID names the instance in the lease, the hostname and the pid when empty
+	ID  string `yaml:"id,omitempty" json:"id,omitempty"`
+	TTL string `yaml:"ttl,omitempty" json:"ttl,omitempty"`
+	// RenewInterval is how often the leader renews the lease, a third of the ttl when empty
+	RenewInterval string `yaml:"renew-interval,omitempty" json:"renew-interval,omitempty"`
+}
+
+// RuntimeConfig converts the ha config into the relayer config
+func (c *HAConfig) RuntimeConfig() (relayer.LeaderElectionConfig, error) {
+	cfg := relayer.LeaderElectionConfig{ID: c.ID}
+	switch c.Backend {
+	case "", "file":
+		if c.LeaseFile == "" {
+			return cfg, fmt.Errorf("ha lease-file is required by the file backend")
+		}
+		cfg.Lease = lease.NewFile(c.LeaseFile)
+	default:
+		return cfg, fmt.Errorf("unsupported ha backend %q", c.Backend)
+	}
+	if c.TTL != "" {
+		ttl, err := time.ParseDuration(c.TTL)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid ha ttl: %w", err)
+		}
+		if ttl < time.Second {
+			return cfg, fmt.Errorf("ha ttl must be at least 1s")
+		}
+		cfg.TTL = ttl
+	}
+	if c.RenewInterval != "" {
+		interval, err := time.ParseDuration(c.RenewInterval)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid ha renew-interval: %w", err)
+		}
+		cfg.RenewInterval = interval
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, fmt.Errorf("ha: %w", err)
+	}
+	if cfg.ID == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return cfg, fmt.Errorf("ha id is required: %w", err)
+		}
+		cfg.ID = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	}
+	return cfg, nil
+}
+
+// AuditConfig configures the reconciliation of the source sn with the destination receipts
+type AuditConfig struct {
+	Interval string `yaml:"interval,omitempty" json:"interval,omitempty"`
+	Requeue  bool   `yaml:"requeue,omitempty" json:"requeue,omitempty"`
+	MaxRange uint64 `yaml:"max-range,omitempty" json:"max-range,omitempty"`
+}
+
+// RuntimeConfig converts the audit config into the relayer config
+func (c *AuditConfig) RuntimeConfig() (relayer.AuditConfig, error) {
+	cfg := relayer.AuditConfig{Requeue: c.Requeue, MaxRange: c.MaxRange}
+	if c.Interval != "" {
+		interval, err := time.ParseDuration(c.Interval)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid audit interval: %w", err)
+		}
+		if interval <= 0 {
+			return cfg, fmt.Errorf("audit interval must be positive")
+		}
+		cfg.Interval = interval
+	}
+	return cfg, nil
+}
+
+// BalanceMonitorConfig configures the low balance alerts of the relayer wallets
+type BalanceMonitorConfig struct {
+	Interval   string                              `yaml:"interval,omitempty" json:"interval,omitempty"`
+	WebhookURL string                              `yaml:"webhook-url,omitempty" json:"webhook-url,omitempty"`
+	Chains     map[string]relayer.BalanceThreshold `yaml:"chains" json:"chains"`
+}
+
+// RuntimeConfig converts the balance monitor config into the relayer config
+func (c *BalanceMonitorConfig) RuntimeConfig() (relayer.BalanceMonitorConfig, error) {
+	cfg := relayer.BalanceMonitorConfig{
+		WebhookURL: c.WebhookURL,
+		Thresholds: c.Chains,
+	}
+	if c.Interval != "" {
+		interval, err := time.ParseDuration(c.Interval)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid balance-monitor interval: %w", err)
+		}
+		cfg.Interval = interval
+	}
+	for chain, threshold := range c.Chains {
+		if err := threshold.Validate(); err != nil {
+			return cfg, fmt.Errorf("balance-monitor chain %s: %w", chain, err)
+		}
+	}
+	return cfg, nil
+}
+
+// Validate reports every problem of the global settings
+func (g *GlobalConfig) Validate() error {
+	var errs provider.ConfigErrors
+	if g.APIListenPort != "" {
+		if _, _, err := net.SplitHostPort(g.APIListenPort); err != nil {
+			errs.Add("api-listen-addr %q: %w", g.APIListenPort, err)
+		}
+	}
+	if g.Timeout != "" {
+		if _, err := time.ParseDuration(g.Timeout); err != nil {
+			errs.Add("timeout: %w", err)
+		}
+	}
+	if g.BackupInterval != "" {
+		if _, err := time.ParseDuration(g.BackupInterval); err != nil {
+			errs.Add("backup-interval: %w", err)
+		} else if g.BackupDir == "" {
+			errs.Add("backup-dir is required with backup-interval")
+		}
+	}
+	if g.BackupKeep < 0 {
+		errs.Add("backup-keep must not be negative")
+	}
+	if g.APITokenFile != "" && g.APITokenEnv != "" {
+		errs.Add("only one of api-token-file and api-token-env can be set")
+	}
+	if g.DBEncryptionKeyFile != "" && g.DBEncryptionKeyEnv != "" {
+		errs.Add("only one of db-encryption-key-file and db-encryption-key-env can be set")
+	}
+	if g.BalanceMonitor != nil {
+		if _, err := g.BalanceMonitor.RuntimeConfig(); err != nil {
+			errs.AddErr(err)
+		}
+	}
+	if g.Audit != nil {
+		if _, err := g.Audit.RuntimeConfig(); err != nil {
+			errs.AddErr(err)
+		}
+	}
+	if g.HA != nil {
+		if _, err := g.HA.RuntimeConfig(); err != nil {
+			errs.AddErr(err)
+		}
+	}
+	return errs.Err()
 }
 
 // newDefaultGlobalConfig returns a global config with defaults set
 func newDefaultGlobalConfig() *GlobalConfig {
 	return &GlobalConfig{
-		APIListenPort:  ":5183",
+		APIListenPort:  "127.0.0.1:5183",
 		Timeout:        "10s",
 		LightCacheSize: 20,
 	}
@@ -166,31 +392,119 @@ func newDefaultGlobalConfig() *GlobalConfig {
 type Config struct {
 	Global *GlobalConfig  `yaml:"global" json:"global"`
 	Chains relayer.Chains `yaml:"chains" json:"chains"`
+	// Paths are named routes between the chains, start can relay a subset of them
+	Paths map[string]*PathConfig `yaml:"paths,omitempty" json:"paths,omitempty"`
+
+	// refs are the ${ENV_VAR} and file:// references resolved in the config file
+	refs configRefs
+	// sources are the provider configs by chain name as read from the config file,
+	// chains with an unchanged source are reused when the config is loaded again
+	sources map[string]string
 }
 
 // validateConfig is used to validate the GlobalConfig values
 func (c *Config) validateConfig() error {
-	// validating config
-	return nil
+	if c.Global == nil {
+		return nil
+	}
+	return c.Global.Validate()
 }
 
 // ConfigOutputWrapper is an intermediary type for writing the config to disk and stdout
 type ConfigOutputWrapper struct {
-	Global          *GlobalConfig   `yaml:"global" json:"global"`
-	ProviderConfigs ProviderConfigs `yaml:"chains" json:"chains"`
+	Global          *GlobalConfig          `yaml:"global" json:"global"`
+	ProviderConfigs ProviderConfigs        `yaml:"chains" json:"chains"`
+	Paths           map[string]*PathConfig `yaml:"paths,omitempty" json:"paths,omitempty"`
 }
 
 // ConfigInputWrapper is an intermediary type for parsing the config.yaml file
 type ConfigInputWrapper struct {
 	Global          *GlobalConfig                         `yaml:"global"`
 	ProviderConfigs map[string]*ProviderConfigYAMLWrapper `yaml:"chains"`
+	Paths           map[string]*PathConfig                `yaml:"paths"`
+}
+
+// Problems returns every problem of the disk config, chains are checked in name order
+func (c *ConfigInputWrapper) Problems() []error {
+	var problems []error
+	if c.Global != nil {
+		problems = append(problems, unjoin(c.Global.Validate(), "global")...)
+	}
+
+	chainNames := make([]string, 0, len(c.ProviderConfigs))
+	for chainName := range c.ProviderConfigs {
+		chainNames = append(chainNames, chainName)
+	}
+	sort.Strings(chainNames)
+
+	nIds := make(map[string]string)
+	for _, chainName := range chainNames {
+		pcfg := c.ProviderConfigs[chainName].Value.(provider.ProviderConfig)
+		where := fmt.Sprintf("chain %s", chainName)
+		problems = append(problems, unjoin(pcfg.Validate(), where)...)
+
+		nId := pcfg.GetNID()
+		if other, ok := nIds[nId]; ok && nId != "" {
+			problems = append(problems, fmt.Errorf("%s: nid %s is already used by chain %s", where, nId, other))
+			continue
+		}
+		nIds[nId] = chainName
+	}
+	return append(problems, pathProblems(c.Paths, nIds)...)
+}
+
+// KeystoreProblems returns the keystores which do not open with their password, every keystore
+// is decrypted so it is left out of Problems which is checked on every reload
+func (c *ConfigInputWrapper) KeystoreProblems() []error {
+	chainNames := make([]string, 0, len(c.ProviderConfigs))
+	for chainName := range c.ProviderConfigs {
+		chainNames = append(chainNames, chainName)
+	}
+	sort.Strings(chainNames)
+
+	var problems []error
+	for _, chainName := range chainNames {
+		if v, ok := c.ProviderConfigs[chainName].Value.(provider.KeystoreValidator); ok {
+			problems = append(problems, unjoin(v.ValidateKeystores(), fmt.Sprintf("chain %s", chainName))...)
+		}
+	}
+	return problems
+}
+
+// unjoin splits the errors joined by the validation and prefixes them with where
+func unjoin(err error, where string) []error {
+	if err == nil {
+		return nil
+	}
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return []error{fmt.Errorf("%s: %w", where, err)}
+	}
+	var errs []error
+	for _, err := range joined.Unwrap() {
+		errs = append(errs, unjoin(err, where)...)
+	}
+	return errs
 }
 
 // RuntimeConfig converts the input disk config into the relayer runtime config.
+// The chains of the current config are reused when their provider config did not change
 func (c *ConfigInputWrapper) RuntimeConfig(ctx context.Context, a *appState) (*Config, error) {
 	// build providers for each chain
 	chains := make(relayer.Chains)
+	sources := make(map[string]string, len(c.ProviderConfigs))
 	for chainName, pcfg := range c.ProviderConfigs {
+		source, err := yaml.Marshal(pcfg.Value)
+		if err != nil {
+			return nil, err
+		}
+		// the keystore is part of the source so a keystore replaced in place is loaded again on reload
+		sources[chainName] = pcfg.Type + "\n" + string(source) + keystoreDigest(pcfg.Value)
+		if chain := a.config.unchangedChain(chainName, sources[chainName]); chain != nil {
+			chains[chain.ChainProvider.NID()] = chain
+			continue
+		}
+
 		prov, err := pcfg.Value.(provider.ProviderConfig).NewProvider(
 			a.log.With(zap.String("provider_type", pcfg.Type)),
 			a.homePath, a.debug, chainName,
@@ -204,15 +518,55 @@ func (c *ConfigInputWrapper) RuntimeConfig(ctx context.Context, a *appState) (*C
 		}
 
 		chain := relayer.NewChain(a.log, prov, a.debug)
+		if other, ok := chains[chain.ChainProvider.NID()]; ok {
+			return nil, fmt.Errorf("chain %s: nid %s is already used by chain %s", chainName, prov.NID(), other.ChainProvider.ChainName())
+		}
 		chains[chain.ChainProvider.NID()] = chain
 	}
 
 	return &Config{
-		Global: c.Global,
-		Chains: chains,
+		Global:  c.Global,
+		Chains:  chains,
+		Paths:   c.Paths,
+		sources: sources,
 	}, nil
 }
 
+// keystoreDigest returns the hash of the keystore files of the provider config, empty without keystore
+func keystoreDigest(pcfg any) string {
+	keyed, ok := pcfg.(interface{ Keys() []wallet.Key })
+	if !ok {
+		return ""
+	}
+	h, read := sha256.New(), false
+	for _, key := range keyed.Keys() {
+		if key.Keystore == "" {
+			continue
+		}
+		if data, err := os.ReadFile(key.Keystore); err == nil {
+			h.Write(data)
+			read = true
+		}
+	}
+	if !read {
+		return ""
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// unchangedChain returns the chain of the config if it was built from the same source
+func (c *Config) unchangedChain(chainName, source string) *relayer.Chain {
+	if c == nil || c.sources[chainName] != source {
+		return nil
+	}
+	for _, chain := range c.Chains {
+		if chain.ChainProvider.ChainName() == chainName {
+			return chain
+		}
+	}
+	return nil
+}
+
 type ProviderConfigs map[string]*ProviderConfigWrapper
 
 // ProviderConfigWrapper is an intermediary type for parsing arbitrary ProviderConfigs from json files and writing to json/yaml files
@@ -307,7 +661,7 @@ func (c *Config) Wrapped() *ConfigOutputWrapper {
 		}
 		providers[chain.ChainProvider.ChainName()] = pcfgw
 	}
-	return &ConfigOutputWrapper{Global: c.Global, ProviderConfigs: providers}
+	return &ConfigOutputWrapper{Global: c.Global, ProviderConfigs: providers, Paths: c.Paths}
 }
 
 func defaultConfigYAML() []byte {
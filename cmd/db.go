@@ -1,10 +1,15 @@
 package cmd
 
 import (
+	"compress/gzip"
 	"fmt"
+	"io"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/icon-project/centralized-relay/relayer"
+	"github.com/icon-project/centralized-relay/relayer/lvldb"
 	"github.com/icon-project/centralized-relay/relayer/store"
 	"github.com/icon-project/centralized-relay/relayer/types"
 	"github.com/spf13/cobra"
@@ -12,10 +17,23 @@ import (
 )
 
 type dbState struct {
-	chain string
-	sn    uint64
-	page  uint
-	limit uint
+	chain      string
+	sn         uint64
+	page       uint
+	limit      uint
+	dryRun     bool
+	file       string
+	onConflict string
+	dir        string
+	keep       int
+	newKeyFile string
+	newKeyEnv  string
+	hashKeys   bool
+	quarantine bool
+	unroutable bool
+	txHash     string
+	relayNow   bool
+	timeout    time.Duration
 }
 
 func NewDBState() dbState {
@@ -49,7 +67,7 @@ func dbCmd(a *appState) *cobra.Command {
 		Short:   "Get messages stored in the database",
 		Aliases: []string{"m"},
 	}
-	messagesCmd.AddCommand(db.messagesList(a))
+	messagesCmd.AddCommand(db.messagesList(a), db.messagesInject(a), db.messagesRelease(a))
 	// TODO: implement remove message from db
 	// messagesCmd.AddCommand(db.messagesRm(a))
 	// TODO: finalize
@@ -62,7 +80,7 @@ func dbCmd(a *appState) *cobra.Command {
 	}
 	blockCmd.AddCommand(db.blockInfo(a))
 
-	dbCMD.AddCommand(messagesCmd, blockCmd)
+	dbCMD.AddCommand(messagesCmd, blockCmd, db.migrate(a), db.export(a), db.importCmd(a), db.backup(a), db.restore(a), db.rotateKey(a))
 	return dbCMD
 }
 
@@ -77,8 +95,18 @@ func (d *dbState) messagesList(app *appState) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			messageStore := rly.GetMessageStore()
+			if d.quarantine && d.unroutable {
+				return fmt.Errorf("select either --quarantined or --unroutable")
+			}
+			if d.quarantine {
+				messageStore = rly.GetQuarantineStore()
+			}
+			if d.unroutable {
+				messageStore = rly.GetUnroutableStore()
+			}
 			pg := store.NewPagination().WithPage(d.page, d.limit)
-			messages, err := rly.GetMessageStore().GetMessages(d.chain, pg)
+			messages, err := messageStore.GetMessages(d.chain, pg)
 			if err != nil {
 				return err
 			}
@@ -101,6 +129,8 @@ func (d *dbState) messagesList(app *appState) *cobra.Command {
 		},
 	}
 	d.dbMessageFlagsListFlags(list)
+	list.Flags().BoolVar(&d.quarantine, "quarantined", false, "list messages quarantined after failing verification")
+	list.Flags().BoolVar(&d.unroutable, "unroutable", false, "list messages kept until their destination chain or route is relayed")
 	return list
 }
 
@@ -219,9 +249,306 @@ func (d *dbState) blockInfo(app *appState) *cobra.Command {
 	return block
 }
 
+func (d *dbState) migrate(app *appState) *cobra.Command {
+	migrate := &cobra.Command{
+		Use:   "migrate",
+		Short: "Upgrade the database to the latest schema version",
+		Args:  withUsage(cobra.NoArgs),
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s db migrate --dry-run
+$ %s db migrate`, appName, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := app.dataStore()
+			if err != nil {
+				return err
+			}
+			migrator := relayer.NewMigrator(db)
+			current, err := migrator.Version()
+			if err != nil {
+				return err
+			}
+			pending, err := migrator.Pending()
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Current schema version: %d\n", current)
+			fmt.Printf("Latest schema version: %d\n", migrator.LatestVersion())
+			if len(pending) == 0 {
+				fmt.Println("Database is up to date")
+				return nil
+			}
+
+			printLabels("Version", "Description")
+			for _, m := range pending {
+				fmt.Printf("%-10d %s\n", m.Version, m.Description)
+			}
+			if d.dryRun {
+				fmt.Printf("Pending migrations: %d (dry run, nothing applied)\n", len(pending))
+				return nil
+			}
+
+			applied, err := migrator.Migrate()
+			fmt.Printf("Applied migrations: %d\n", len(applied))
+			return err
+		},
+	}
+	migrate.Flags().BoolVar(&d.dryRun, "dry-run", false, "only list the pending migrations without applying them")
+	return migrate
+}
+
+func (d *dbState) export(app *appState) *cobra.Command {
+	export := &cobra.Command{
+		Use:   "export",
+		Short: "Export messages, block heights, finality objects, parked messages, pauses and audits as jsonl",
+		Args:  withUsage(cobra.NoArgs),
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s db export --file relayer.jsonl
+$ %s db export --chain 0x2.icon --file icon.jsonl.gz`, appName, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rly, err := d.GetRelayer(app)
+			if err != nil {
+				return err
+			}
+
+			var stats *relayer.ExportStats
+			if d.file == "" {
+				stats, err = rly.Export(cmd.OutOrStdout(), d.chain)
+			} else {
+				stats, err = exportFile(rly, d.file, d.chain)
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Exported messages: %d, blocks: %d, finality: %d, quarantined: %d, unroutable: %d, pauses: %d, audits: %d\n",
+				stats.Messages, stats.Blocks, stats.Finality, stats.Quarantined, stats.Unroutable, stats.Pauses, stats.Audits)
+			return nil
+		},
+	}
+	export.Flags().StringVarP(&d.chain, "chain", "c", "", "only export records of the chain")
+	export.Flags().StringVarP(&d.file, flagFile, "f", "", "output file, gzip compressed if it ends with .gz (default stdout)")
+	return export
+}
+
+// exportFile writes the export to the file, gzip compressed if it ends with .gz. The gzip writer
+// and the file are closed before the export succeeds, so a failed final write is not lost
+func exportFile(rly *relayer.Relayer, file, nId string) (*relayer.ExportStats, error) {
+	f, err := os.Create(file)
+	if err != nil {
+		return nil, err
+	}
+	var w io.Writer = f
+	var gw *gzip.Writer
+	if strings.HasSuffix(file, ".gz") {
+		gw = gzip.NewWriter(f)
+		w = gw
+	}
+	stats, err := rly.Export(w, nId)
+	if gw != nil {
+		if cerr := gw.Close(); err == nil {
+			err = cerr
+		}
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (d *dbState) importCmd(app *appState) *cobra.Command {
+	imp := &cobra.Command{
+		Use:   "import",
+		Short: "Import records written by db export",
+		Args:  withUsage(cobra.NoArgs),
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s db import --file relayer.jsonl
+$ %s db import --file icon.jsonl.gz --on-conflict overwrite`, appName, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			policy := relayer.ConflictPolicy(d.onConflict)
+			if err := policy.Validate(); err != nil {
+				return err
+			}
+			rly, err := d.GetRelayer(app)
+			if err != nil {
+				return err
+			}
+
+			f, err := os.Open(d.file)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			var r io.Reader = f
+			if strings.HasSuffix(d.file, ".gz") {
+				gr, err := gzip.NewReader(f)
+				if err != nil {
+					return err
+				}
+				defer gr.Close()
+				r = gr
+			}
+
+			stats, err := rly.Import(r, d.chain, policy)
+			if stats != nil {
+				fmt.Fprintf(cmd.OutOrStdout(), "Imported messages: %d, blocks: %d, finality: %d, quarantined: %d, unroutable: %d, pauses: %d, audits: %d, skipped: %d, overwritten: %d\n",
+					stats.Messages, stats.Blocks, stats.Finality, stats.Quarantined, stats.Unroutable, stats.Pauses, stats.Audits, stats.Skipped, stats.Overwrote)
+			}
+			return err
+		},
+	}
+	imp.Flags().StringVarP(&d.chain, "chain", "c", "", "only import records of the chain")
+	imp.Flags().StringVarP(&d.file, flagFile, "f", "", "file written by db export, gzip compressed if it ends with .gz")
+	imp.Flags().StringVar(&d.onConflict, "on-conflict", string(relayer.ConflictSkip), "how to handle existing records (skip, overwrite or fail)")
+	if err := imp.MarkFlagRequired(flagFile); err != nil {
+		panic(err)
+	}
+	return imp
+}
+
+func (d *dbState) backup(app *appState) *cobra.Command {
+	backup := &cobra.Command{
+		Use:   "backup",
+		Short: "Backup a consistent snapshot of the database",
+		Args:  withUsage(cobra.NoArgs),
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s db backup --dir ~/relayer-backups --keep 7`, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, keep := d.dir, d.keep
+			if app.config != nil && app.config.Global != nil {
+				global := app.config.Global
+				if dir == "" {
+					dir = global.BackupDir
+				}
+				if !cmd.Flags().Changed("keep") && global.BackupKeep > 0 {
+					keep = global.BackupKeep
+				}
+			}
+			if dir == "" {
+				return fmt.Errorf("backup directory is required, use --dir or backup-dir in config")
+			}
+			db, err := app.levelDB()
+			if err != nil {
+				return err
+			}
+			path, err := db.Backup(dir, keep)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Backup written to %s\n", path)
+			return nil
+		},
+	}
+	backup.Flags().StringVar(&d.dir, "dir", "", "backup directory (default backup-dir from config)")
+	backup.Flags().IntVar(&d.keep, "keep", 0, "number of backups to retain, 0 keeps all")
+	return backup
+}
+
+func (d *dbState) restore(app *appState) *cobra.Command {
+	restore := &cobra.Command{
+		Use:   "restore [backup]",
+		Short: "Replace the database with a backup, the latest backup is used when not provided",
+		Long: strings.TrimSpace(`Replace the database with a backup, the latest backup is used when not provided.
+The backup is copied next to the database and swapped in once verified, the relayer must be stopped.`),
+		Args: withUsage(cobra.MaximumNArgs(1)),
+		Annotations: map[string]string{
+			// the database is opened by the command, a relayer holding it is reported as such
+			annotationSkipDB: "true",
+		},
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s db restore --dir ~/relayer-backups
+$ %s db restore ~/relayer-backups/backup-20240101T000000.000000000Z`, appName, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var path string
+			if len(args) == 1 {
+				path = args[0]
+			} else {
+				dir := d.dir
+				if dir == "" && app.config != nil && app.config.Global != nil {
+					dir = app.config.Global.BackupDir
+				}
+				if dir == "" {
+					return fmt.Errorf("backup path or --dir is required")
+				}
+				backups, err := lvldb.ListBackups(dir)
+				if err != nil {
+					return err
+				}
+				if len(backups) == 0 {
+					return fmt.Errorf("no backup found in %s", dir)
+				}
+				path = backups[len(backups)-1]
+			}
+			if app.dbBackend != "" && app.dbBackend != dbBackendLevelDB {
+				return fmt.Errorf("operation requires the %s db backend", dbBackendLevelDB)
+			}
+			db, err := lvldb.NewLvlDB(app.dbPath, false)
+			if err != nil {
+				return fmt.Errorf("failed to open the database, stop the relayer before restoring: %w", err)
+			}
+			defer db.Close()
+			if err := db.Restore(path); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Database restored from %s\n", path)
+			return nil
+		},
+	}
+	restore.Flags().StringVar(&d.dir, "dir", "", "backup directory to pick the latest backup from (default backup-dir from config)")
+	return restore
+}
+
+func (d *dbState) rotateKey(app *appState) *cobra.Command {
+	rotate := &cobra.Command{
+		Use:   "rotate-key",
+		Short: "Re-encrypt the database with a new encryption key",
+		Long: strings.TrimSpace(`Re-encrypt the database with a new encryption key.
+The current key is taken from the config, an unencrypted database gets encrypted.
+The data is rewritten in a single batch, a crash leaves it under either the old or the new key.
+Update db-encryption-key-file or db-encryption-key-env, and db-hash-keys when changed, in the config afterwards.`),
+		Args: withUsage(cobra.NoArgs),
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s db rotate-key --new-key-file ~/.centralized-relay/db.key
+$ %s db rotate-key --new-key-env RELAYER_DB_KEY --hash-keys`, appName, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			newKey, err := store.LoadEncryptionKey(d.newKeyFile, d.newKeyEnv)
+			if err != nil {
+				return err
+			}
+			if newKey == nil {
+				return fmt.Errorf("new key is required, use --new-key-file or --new-key-env")
+			}
+			oldKey, oldHashKeys, err := app.encryptionKey()
+			if err != nil {
+				return err
+			}
+
+			hashKeys := oldHashKeys
+			if cmd.Flags().Changed("hash-keys") {
+				hashKeys = d.hashKeys
+			}
+			count, err := store.RotateEncryptionKey(app.db, oldKey, oldHashKeys, newKey, hashKeys)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Re-encrypted %d records, update the encryption key in the config\n", count)
+			return nil
+		},
+	}
+	rotate.Flags().StringVar(&d.newKeyFile, "new-key-file", "", "file with the hex encoded new key")
+	rotate.Flags().StringVar(&d.newKeyEnv, "new-key-env", "", "environment variable with the hex encoded new key")
+	rotate.Flags().BoolVar(&d.hashKeys, "hash-keys", false, "hmac the database keys with the new key (default db-hash-keys from config)")
+	return rotate
+}
+
 // GetRelayer returns the relayer instance
 func (d *dbState) GetRelayer(app *appState) (*relayer.Relayer, error) {
-	rly, err := relayer.NewRelayer(app.log, app.db, app.config.Chains.GetAll(), false)
+	db, err := app.dataStore()
+	if err != nil {
+		return nil, err
+	}
+	rly, err := relayer.NewRelayer(app.log, db, app.config.Chains.GetAll(), false)
 	if err != nil {
 		app.log.Fatal("failed to create relayer", zap.Error(err))
 		return nil, err
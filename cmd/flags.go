@@ -19,6 +19,7 @@ const (
 	flagFresh           = "fresh"
 	flagFile            = "file"
 	flagConfig          = "config"
+	flagWatchConfig     = "watch-config"
 )
 
 func flushIntervalFlag(v *viper.Viper, cmd *cobra.Command) *cobra.Command {
@@ -37,6 +38,14 @@ func freshFlag(v *viper.Viper, cmd *cobra.Command) *cobra.Command {
 	return cmd
 }
 
+func watchConfigFlag(v *viper.Viper, cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().Bool(flagWatchConfig, true, "reload the chains when the config file changes")
+	if err := v.BindPFlag(flagWatchConfig, cmd.Flags().Lookup(flagWatchConfig)); err != nil {
+		panic(err)
+	}
+	return cmd
+}
+
 func yamlFlag(v *viper.Viper, cmd *cobra.Command) *cobra.Command {
 	cmd.Flags().BoolP(flagYAML, "y", false, "output using yaml")
 	if err := v.BindPFlag(flagYAML, cmd.Flags().Lookup(flagYAML)); err != nil {
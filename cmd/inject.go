@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/icon-project/centralized-relay/relayer"
+	"github.com/spf13/cobra"
+)
+
+func (d *dbState) messagesInject(app *appState) *cobra.Command {
+	inject := &cobra.Command{
+		Use:   "inject",
+		Short: "Relay the messages emitted by a source transaction",
+		Long: strings.TrimSpace(`Relay the messages emitted by a source transaction.
+The messages are parsed from the receipt of the transaction and stored for the relayer to deliver,
+the ones already received or being relayed are left out. With --relay-now they are delivered
+right away and the command waits for the destination transactions, failed deliveries are retried by the relayer.
+Messages on a paused route or to a destination with a critical balance are queued instead, a transaction
+still pending at the timeout is reported as submitted and queued only if it fails.
+The messages are injected into the running relayer through the api, into the database when no relayer runs.`),
+		Args: withUsage(cobra.NoArgs),
+		Annotations: map[string]string{
+			annotationSkipDB: "true",
+		},
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s db messages inject --chain 0x2.icon --tx 0x4d5e...
+$ %s db messages inject -c 0x13881.mumbai --tx 0x9f1c... --relay-now`, appName, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsn, err := cmd.Flags().GetBool(flagJSON)
+			if err != nil {
+				return err
+			}
+			opts := relayer.InjectOptions{Chain: d.chain, TxHash: d.txHash, RelayNow: d.relayNow, Timeout: d.timeout}
+			body, err := json.Marshal(opts)
+			if err != nil {
+				return err
+			}
+
+			var injected []*relayer.InjectedMessage
+			// the api call outlives the delivery timeout of the relayer
+			err = app.callAPITimeout(d.timeout+defaultAPITimeout, http.MethodPost, "/inject", nil, body, &injected)
+			if apiUnreachable(err) {
+				rly, rlyErr := app.offlineRelayer()
+				if rlyErr != nil {
+					return rlyErr
+				}
+				injected, err = rly.Inject(cmd.Context(), opts)
+			}
+			if err != nil {
+				return err
+			}
+
+			if jsn {
+				out, err := json.Marshal(injected)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), string(out))
+				return nil
+			}
+			return printInjected(cmd.OutOrStdout(), injected)
+		},
+	}
+	d.messageChainFlag(inject)
+	inject.Flags().StringVar(&d.txHash, "tx", "", "hash of the source transaction")
+	if err := inject.MarkFlagRequired("tx"); err != nil {
+		panic(err)
+	}
+	inject.Flags().BoolVar(&d.relayNow, "relay-now", false, "deliver the messages and wait for the destination transactions")
+	inject.Flags().DurationVar(&d.timeout, "timeout", relayer.DefaultInjectTimeout, "maximum wait for a delivery with --relay-now")
+	return jsonFlag(app.viper, inject)
+}
+
+func printInjected(out io.Writer, injected []*relayer.InjectedMessage) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SRC\tDST\tSN\tHEIGHT\tSTATUS\tTX\tERROR")
+	for _, m := range injected {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\t%s\t%s\n", m.Src, m.Dst, m.Sn, m.Height, m.Status, m.TxHash, m.Error)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	secretFilePrefix = "file://"
+	redactedValue    = "REDACTED"
+)
+
+var envRefPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// secretKeys are the config keys whose values are redacted when the config is printed
+var secretKeys = map[string]bool{
+	"password":    true,
+	"webhook-url": true,
+	"token":       true,
+	"pin":         true,
+}
+
+// configRef is a value of the config file that references the environment or a secret file
+type configRef struct {
+	raw      string
+	resolved string
+}
+
+// configRefs holds the references of the config file by the path of their value, e.g. chains.icon.value.password
+type configRefs map[string]configRef
+
+// interpolate resolves the ${ENV_VAR} and file:// references of the scalar values under node
+func (refs configRefs) interpolate(node *yaml.Node) error {
+	var err error
+	walkScalars(node, "", func(scalar *yaml.Node, path, _ string) {
+		if err != nil {
+			return
+		}
+		resolved, resolveErr := resolveRef(scalar.Value)
+		if resolveErr != nil {
+			err = fmt.Errorf("config %s: %w", path, resolveErr)
+			return
+		}
+		if resolved != scalar.Value {
+			refs[path] = configRef{raw: scalar.Value, resolved: resolved}
+			// the tag is resolved again from the value, so references work for numbers too
+			scalar.Value, scalar.Tag, scalar.Style = resolved, "", 0
+		}
+	})
+	return err
+}
+
+// restore puts the references back in place of the values that were not changed since they were resolved
+func (refs configRefs) restore(node *yaml.Node) {
+	walkScalars(node, "", func(scalar *yaml.Node, path, _ string) {
+		if ref, ok := refs[path]; ok && ref.resolved == scalar.Value {
+			scalar.Value, scalar.Tag, scalar.Style = ref.raw, "!!str", 0
+		}
+	})
+}
+
+// redact hides the values of the secret keys, references are kept as they do not disclose the secret
+func (refs configRefs) redact(node *yaml.Node) {
+	walkScalars(node, "", func(scalar *yaml.Node, path, key string) {
+		if !secretKeys[key] || scalar.Value == "" {
+			return
+		}
+		if ref, ok := refs[path]; ok && ref.raw == scalar.Value {
+			return
+		}
+		scalar.Value, scalar.Tag, scalar.Style = redactedValue, "!!str", 0
+	})
+}
+
+// encode marshals v with the references restored, secrets are redacted when redact is set
+func (refs configRefs) encode(v any, redact bool) (*yaml.Node, error) {
+	node := new(yaml.Node)
+	if err := node.Encode(v); err != nil {
+		return nil, err
+	}
+	refs.restore(node)
+	if redact {
+		refs.redact(node)
+	}
+	return node, nil
+}
+
+// readConfigFile reads the config file with its references resolved
+func readConfigFile(path string) (*ConfigInputWrapper, configRefs, error) {
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading file: %w", err)
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(file, &node); err != nil {
+		return nil, nil, fmt.Errorf("error unmarshalling config: %w", err)
+	}
+	refs := make(configRefs)
+	if err := refs.interpolate(&node); err != nil {
+		return nil, nil, err
+	}
+	cfgWrapper := &ConfigInputWrapper{}
+	if node.Kind == 0 {
+		return cfgWrapper, refs, nil
+	}
+	if err := node.Decode(cfgWrapper); err != nil {
+		return nil, nil, fmt.Errorf("error unmarshalling config: %w", err)
+	}
+	return cfgWrapper, refs, nil
+}
+
+// interpolateJSON resolves the references of a json document
+func interpolateJSON(data []byte) ([]byte, configRefs, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, nil, err
+	}
+	refs := make(configRefs)
+	if err := refs.interpolate(&node); err != nil {
+		return nil, nil, err
+	}
+	var v any
+	if err := node.Decode(&v); err != nil {
+		return nil, nil, err
+	}
+	data, err := json.Marshal(v)
+	return data, refs, err
+}
+
+func resolveRef(value string) (string, error) {
+	if strings.HasPrefix(value, secretFilePrefix) {
+		path := strings.TrimPrefix(value, secretFilePrefix)
+		secret, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("secret file: %w", err)
+		}
+		return strings.TrimRight(string(secret), "\r\n"), nil
+	}
+
+	var err error
+	resolved := envRefPattern.ReplaceAllStringFunc(value, func(ref string) string {
+		name := envRefPattern.FindStringSubmatch(ref)[1]
+		env, ok := os.LookupEnv(name)
+		if !ok && err == nil {
+			err = fmt.Errorf("environment variable %s is not set", name)
+		}
+		return env
+	})
+	return resolved, err
+}
+
+func walkScalars(node *yaml.Node, path string, fn func(scalar *yaml.Node, path, key string)) {
+	walk(node, path, "", fn)
+}
+
+func walk(node *yaml.Node, path, key string, fn func(scalar *yaml.Node, path, key string)) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			walk(child, path, key, fn)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+			walk(node.Content[i+1], joinPath(path, key), key, fn)
+		}
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			walk(child, joinPath(path, strconv.Itoa(i)), key, fn)
+		}
+	case yaml.ScalarNode:
+		fn(node, path, key)
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
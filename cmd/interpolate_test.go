@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestInterpolate(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "password")
+	require.NoError(t, os.WriteFile(secretFile, []byte("from-file\n"), 0600))
+	t.Setenv("TEST_RELAY_PASSWORD", "from-env")
+	t.Setenv("TEST_RELAY_GAS", "100")
+
+	in := `
+chains:
+  a:
+    password: ${TEST_RELAY_PASSWORD}
+    gas-price: ${TEST_RELAY_GAS}
+    url: http://${TEST_RELAY_PASSWORD}:80
+  b:
+    password: file://` + secretFile + `
+    plain: value
+  c:
+    password: plain-secret
+`
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(in), &node))
+	refs := make(configRefs)
+	require.NoError(t, refs.interpolate(&node))
+
+	var resolved struct {
+		Chains map[string]struct {
+			Password string `yaml:"password"`
+			GasPrice int64  `yaml:"gas-price"`
+			URL      string `yaml:"url"`
+			Plain    string `yaml:"plain"`
+		} `yaml:"chains"`
+	}
+	require.NoError(t, node.Decode(&resolved))
+	assert.Equal(t, "from-env", resolved.Chains["a"].Password)
+	assert.Equal(t, int64(100), resolved.Chains["a"].GasPrice)
+	assert.Equal(t, "http://from-env:80", resolved.Chains["a"].URL)
+	assert.Equal(t, "from-file", resolved.Chains["b"].Password)
+	assert.Len(t, refs, 4)
+
+	// references are written back, secrets are redacted only when shown
+	out, err := refs.encode(resolved, false)
+	require.NoError(t, err)
+	b, err := yaml.Marshal(out)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "password: ${TEST_RELAY_PASSWORD}")
+	assert.Contains(t, string(b), "gas-price: ${TEST_RELAY_GAS}")
+	assert.Contains(t, string(b), "password: plain-secret")
+
+	out, err = refs.encode(resolved, true)
+	require.NoError(t, err)
+	b, err = yaml.Marshal(out)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "password: file://"+secretFile)
+	assert.Contains(t, string(b), "password: REDACTED")
+	assert.NotContains(t, string(b), "plain-secret")
+	assert.NotContains(t, string(b), "from-")
+
+	t.Setenv("TEST_RELAY_PASSWORD", "")
+	require.NoError(t, os.Unsetenv("TEST_RELAY_PASSWORD"))
+	require.NoError(t, yaml.Unmarshal([]byte(in), &node))
+	assert.ErrorContains(t, make(configRefs).interpolate(&node), "TEST_RELAY_PASSWORD is not set")
+}
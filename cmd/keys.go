@@ -0,0 +1,453 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/icon-project/centralized-relay/relayer/chains/evm"
+	"github.com/icon-project/centralized-relay/relayer/chains/icon"
+	"github.com/icon-project/centralized-relay/relayer/provider"
+	"github.com/spf13/cobra"
+)
+
+const keysDir = "keys"
+
+// keystoreCodec creates and reads the keystores of a chain type
+type keystoreCodec struct {
+	// encrypt returns the keystore of the private key and its address, a key is generated when privateKey is nil
+	encrypt func(privateKey []byte, password string) ([]byte, string, error)
+	// decrypt returns the address and the private key of the keystore
+	decrypt func(data []byte, password string) (string, []byte, error)
+}
+
+// NOTE: Add new chain types with a keystore wallet here and in keystoreFields
+var keystoreCodecs = map[string]keystoreCodec{
+	"evm":  {encrypt: evm.NewKeystore, decrypt: evm.DecryptKeystore},
+	"icon": {encrypt: icon.NewKeystore, decrypt: icon.DecryptKeystore},
+}
+
+// keystoreFields returns the keystore path and password fields of the provider config
+func keystoreFields(pcfg any) (*string, *string, error) {
+	switch c := pcfg.(type) {
+	case *evm.EVMProviderConfig:
+		return &c.Keystore, &c.Password, nil
+	case *icon.IconProviderConfig:
+		return &c.KeyStore, &c.Password, nil
+	default:
+		return nil, nil, fmt.Errorf("chain type %T has no keystore", pcfg)
+	}
+}
+
+// keyChain is a chain of the config file with its keystore settings
+type keyChain struct {
+	name     string
+	nid      string
+	keystore *string
+	password *string
+	codec    keystoreCodec
+	// dir holds the keystores of the chain
+	dir string
+}
+
+// keyState holds the config file read by the keys commands,
+// they work on the file directly so a chain can be given its first key
+type keyState struct {
+	a    *appState
+	cfg  *ConfigInputWrapper
+	refs configRefs
+	// password is the --password flag, it defaults to the password of the chain config
+	password string
+}
+
+func keysCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "keys",
+		Aliases: []string{"k"},
+		Short:   "Manage the relayer wallets of the configured chains",
+		Long: strings.TrimSpace(`Manage the relayer wallets of the configured chains.
+Keystores are stored under the keys directory of the home, per chain nid,
+the chain config is updated to use the key that is added or imported.`),
+	}
+
+	cmd.AddCommand(
+		keysAddCmd(a),
+		keysImportCmd(a),
+		keysListCmd(a),
+		keysShowCmd(a),
+		keysExportCmd(a),
+		keysDeleteCmd(a),
+	)
+
+	return cmd
+}
+
+func keysAddCmd(a *appState) *cobra.Command {
+	state := &keyState{a: a}
+	cmd := &cobra.Command{
+		Use:   "add chain",
+		Short: "Generate a new key and use it as the wallet of the chain",
+		Args:  withUsage(cobra.ExactArgs(1)),
+		Annotations: map[string]string{
+			annotationSkipConfig: "true",
+		},
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s keys add 0x2.icon
+$ %s keys add avalanche --password '${AVAX_KEYSTORE_PASSWORD}'`, appName, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return state.useNewKey(cmd, args[0], nil)
+		},
+	}
+	return passwordFlag(cmd, state)
+}
+
+func keysImportCmd(a *appState) *cobra.Command {
+	state := &keyState{a: a}
+	var privateKey, keystoreFile, keystorePassword string
+	cmd := &cobra.Command{
+		Use:   "import chain",
+		Short: "Import a private key or a keystore and use it as the wallet of the chain",
+		Long: strings.TrimSpace(`Import a private key or a keystore and use it as the wallet of the chain.
+The key is stored in a new keystore encrypted with the password of the chain.`),
+		Args: withUsage(cobra.ExactArgs(1)),
+		Annotations: map[string]string{
+			annotationSkipConfig: "true",
+		},
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s keys import 0x2.icon --keystore ./goloop-keystore.json --keystore-password gochain
+$ %s keys import avalanche --private-key 0x4c0883a6...`, appName, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			switch {
+			case privateKey != "" && keystoreFile != "":
+				return errors.New("only one of --private-key and --keystore can be set")
+			case privateKey != "":
+				key, err := hex.DecodeString(strings.TrimPrefix(privateKey, "0x"))
+				if err != nil {
+					return fmt.Errorf("invalid private key: %w", err)
+				}
+				return state.useNewKey(cmd, args[0], func(*keyChain) ([]byte, error) {
+					return key, nil
+				})
+			case keystoreFile != "":
+				data, err := os.ReadFile(keystoreFile)
+				if err != nil {
+					return err
+				}
+				return state.useNewKey(cmd, args[0], func(chain *keyChain) ([]byte, error) {
+					_, key, err := chain.codec.decrypt(data, keystorePassword)
+					if err != nil {
+						return nil, fmt.Errorf("failed to decrypt %s: %w", keystoreFile, err)
+					}
+					return key, nil
+				})
+			default:
+				return errors.New("one of --private-key or --keystore is required")
+			}
+		},
+	}
+	cmd.Flags().StringVar(&privateKey, "private-key", "", "hex encoded private key to import")
+	cmd.Flags().StringVar(&keystoreFile, "keystore", "", "keystore file to import")
+	cmd.Flags().StringVar(&keystorePassword, "keystore-password", "", "password of the imported keystore file")
+	return passwordFlag(cmd, state)
+}
+
+func keysListCmd(a *appState) *cobra.Command {
+	state := &keyState{a: a}
+	cmd := &cobra.Command{
+		Use:     "list chain",
+		Aliases: []string{"l"},
+		Short:   "List the keys stored for the chain, the key in use is marked with *",
+		Args:    withUsage(cobra.ExactArgs(1)),
+		Annotations: map[string]string{
+			annotationSkipConfig: "true",
+		},
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s keys list 0x2.icon`, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			chain, err := state.chain(args[0])
+			if err != nil {
+				return err
+			}
+			addresses, err := chain.addresses()
+			if err != nil {
+				return err
+			}
+			if len(addresses) == 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "no key stored for %s\n", chain.nid)
+				return nil
+			}
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			for _, addr := range addresses {
+				inUse := ""
+				if chain.inUse(addr) {
+					inUse = "*"
+				}
+				fmt.Fprintf(w, "%s\t%s\n", inUse, addr)
+			}
+			return w.Flush()
+		},
+	}
+	return cmd
+}
+
+func keysShowCmd(a *appState) *cobra.Command {
+	state := &keyState{a: a}
+	cmd := &cobra.Command{
+		Use:   "show chain",
+		Short: "Show the address of the wallet used by the chain",
+		Args:  withUsage(cobra.ExactArgs(1)),
+		Annotations: map[string]string{
+			annotationSkipConfig: "true",
+		},
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s keys show 0x2.icon`, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			chain, err := state.chain(args[0])
+			if err != nil {
+				return err
+			}
+			addr, _, err := chain.decrypt(*chain.keystore, state.resolvedPassword(chain))
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), addr)
+			return nil
+		},
+	}
+	return passwordFlag(cmd, state)
+}
+
+func keysExportCmd(a *appState) *cobra.Command {
+	state := &keyState{a: a}
+	cmd := &cobra.Command{
+		Use:   "export chain [address]",
+		Short: "Print the hex encoded private key of the wallet used by the chain, or of a stored key",
+		Args:  withUsage(cobra.RangeArgs(1, 2)),
+		Annotations: map[string]string{
+			annotationSkipConfig: "true",
+		},
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s keys export 0x2.icon
+$ %s keys export 0x2.icon hxb6b5791be0b5ef67063b3c10b840fb81514db2fd --password gochain`, appName, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			chain, err := state.chain(args[0])
+			if err != nil {
+				return err
+			}
+			keystore := *chain.keystore
+			if len(args) == 2 {
+				keystore = chain.path(args[1])
+			}
+			_, key, err := chain.decrypt(keystore, state.resolvedPassword(chain))
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.ErrOrStderr(), "warning: anyone with the private key controls the wallet, keep it secret")
+			fmt.Fprintln(cmd.OutOrStdout(), hex.EncodeToString(key))
+			return nil
+		},
+	}
+	return passwordFlag(cmd, state)
+}
+
+func keysDeleteCmd(a *appState) *cobra.Command {
+	state := &keyState{a: a}
+	cmd := &cobra.Command{
+		Use:     "delete chain address",
+		Aliases: []string{"d"},
+		Short:   "Delete a stored key, the key in use by the chain cannot be deleted",
+		Args:    withUsage(cobra.ExactArgs(2)),
+		Annotations: map[string]string{
+			annotationSkipConfig: "true",
+		},
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s keys delete 0x2.icon hxb6b5791be0b5ef67063b3c10b840fb81514db2fd`, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			chain, err := state.chain(args[0])
+			if err != nil {
+				return err
+			}
+			if chain.inUse(args[1]) {
+				return fmt.Errorf("key %s is used by chain %s, add or import another key first", args[1], chain.name)
+			}
+			if err := os.Remove(chain.path(args[1])); err != nil {
+				if errors.Is(err, os.ErrNotExist) {
+					return fmt.Errorf("key %s not found for %s", args[1], chain.nid)
+				}
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "key %s deleted\n", args[1])
+			return nil
+		},
+	}
+	return cmd
+}
+
+func passwordFlag(cmd *cobra.Command, state *keyState) *cobra.Command {
+	cmd.Flags().StringVar(&state.password, "password", "",
+		"keystore password, ${ENV_VAR} and file:// references are kept in the config (default the password of the chain config)")
+	return cmd
+}
+
+// useNewKey stores a keystore of the private key returned by key and sets it as the wallet of the chain,
+// a key is generated when key is nil
+func (s *keyState) useNewKey(cmd *cobra.Command, nameOrNID string, key func(*keyChain) ([]byte, error)) error {
+	return s.a.withConfigLock(func() error {
+		chain, err := s.chain(nameOrNID)
+		if err != nil {
+			return err
+		}
+		var privateKey []byte
+		if key != nil {
+			if privateKey, err = key(chain); err != nil {
+				return err
+			}
+		}
+
+		if err := s.setPassword(chain); err != nil {
+			return err
+		}
+		data, addr, err := chain.codec.encrypt(privateKey, *chain.password)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(chain.dir, 0o700); err != nil {
+			return err
+		}
+		keystore := chain.path(addr)
+		if _, err := os.Stat(keystore); err == nil {
+			return fmt.Errorf("key %s is already stored at %s", addr, keystore)
+		}
+		if err := os.WriteFile(keystore, data, 0o600); err != nil {
+			return err
+		}
+		*chain.keystore = keystore
+
+		if err := s.a.writeConfigFile(s.output(), s.refs); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s: %s\n", chain.name, addr)
+		return nil
+	})
+}
+
+// chain reads the config file and returns the chain by name or nid
+func (s *keyState) chain(nameOrNID string) (*keyChain, error) {
+	cfg, refs, err := readConfigFile(s.a.configPath)
+	if err != nil {
+		return nil, err
+	}
+	s.cfg, s.refs = cfg, refs
+
+	for name, pcfg := range cfg.ProviderConfigs {
+		nId := pcfg.Value.(provider.ProviderConfig).GetNID()
+		if name != nameOrNID && nId != nameOrNID {
+			continue
+		}
+		codec, ok := keystoreCodecs[pcfg.Type]
+		if !ok {
+			return nil, fmt.Errorf("chain type %s has no keystore", pcfg.Type)
+		}
+		keystore, password, err := keystoreFields(pcfg.Value)
+		if err != nil {
+			return nil, err
+		}
+		if nId == "" {
+			return nil, fmt.Errorf("chain %s: nid is required", name)
+		}
+		return &keyChain{
+			name:     name,
+			nid:      nId,
+			keystore: keystore,
+			password: password,
+			codec:    codec,
+			dir:      filepath.Join(s.a.homePath, keysDir, nId),
+		}, nil
+	}
+	return nil, errChainNotFound(nameOrNID)
+}
+
+// setPassword sets the --password flag as the password of the chain, references are resolved
+// and written back to the config file
+func (s *keyState) setPassword(chain *keyChain) error {
+	if s.password == "" {
+		if *chain.password == "" {
+			return fmt.Errorf("chain %s has no password, set one with --password", chain.name)
+		}
+		return nil
+	}
+	resolved, err := resolveRef(s.password)
+	if err != nil {
+		return fmt.Errorf("password: %w", err)
+	}
+	path := joinPath("chains."+chain.name, "value.password")
+	delete(s.refs, path)
+	if resolved != s.password {
+		s.refs[path] = configRef{raw: s.password, resolved: resolved}
+	}
+	*chain.password = resolved
+	return nil
+}
+
+// resolvedPassword returns the --password flag with its references resolved, or the password of the chain
+func (s *keyState) resolvedPassword(chain *keyChain) string {
+	if s.password == "" {
+		return *chain.password
+	}
+	if resolved, err := resolveRef(s.password); err == nil {
+		return resolved
+	}
+	return s.password
+}
+
+func (s *keyState) output() *ConfigOutputWrapper {
+	providers := make(ProviderConfigs, len(s.cfg.ProviderConfigs))
+	for name, pcfg := range s.cfg.ProviderConfigs {
+		providers[name] = &ProviderConfigWrapper{Type: pcfg.Type, Value: pcfg.Value.(provider.ProviderConfig)}
+	}
+	return &ConfigOutputWrapper{Global: s.cfg.Global, ProviderConfigs: providers, Paths: s.cfg.Paths}
+}
+
+func (c *keyChain) path(addr string) string {
+	return filepath.Join(c.dir, addr+".json")
+}
+
+func (c *keyChain) inUse(addr string) bool {
+	inUse, err := filepath.Abs(*c.keystore)
+	if err != nil {
+		return false
+	}
+	stored, err := filepath.Abs(c.path(addr))
+	return err == nil && inUse == stored
+}
+
+// addresses returns the addresses of the keys stored for the chain
+func (c *keyChain) addresses() ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(c.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	addresses := make([]string, 0, len(files))
+	for _, file := range files {
+		addresses = append(addresses, strings.TrimSuffix(filepath.Base(file), ".json"))
+	}
+	sort.Strings(addresses)
+	return addresses, nil
+}
+
+func (c *keyChain) decrypt(keystore, password string) (string, []byte, error) {
+	data, err := os.ReadFile(keystore)
+	if err != nil {
+		return "", nil, err
+	}
+	addr, key, err := c.codec.decrypt(data, password)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to decrypt %s: %w", keystore, err)
+	}
+	return addr, key, nil
+}
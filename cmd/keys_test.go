@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const testKeysConfig = `
+chains:
+  icon:
+    type: icon
+    value:
+      rpc-url: http://127.0.0.1:9080/api/v3
+      nid: 0x2.icon
+      network-id: 2
+      contract-address: cx0000000000000000000000000000000000000001
+      password: ${TEST_KEYS_PASSWORD}
+`
+
+func runKeys(t *testing.T, home string, args ...string) (string, error) {
+	t.Helper()
+	var out bytes.Buffer
+	cmd := NewRootCmd(zap.NewNop())
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(append([]string{"--home", home, "--config-path", filepath.Join(home, "config.yaml"), "keys"}, args...))
+	err := cmd.Execute()
+	// only the trailing newline is dropped, the first line of a list is indented too
+	return strings.TrimRight(out.String(), "\n"), err
+}
+
+func TestKeys(t *testing.T) {
+	t.Setenv("TEST_KEYS_PASSWORD", "gochain")
+	home := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(home, "config.yaml"), []byte(testKeysConfig), 0o600))
+
+	out, err := runKeys(t, home, "import", "0x2.icon", "--keystore", "../example/wallets/icon/keystore.json", "--keystore-password", "gochain")
+	require.NoError(t, err)
+	assert.Equal(t, "icon: hxb6b5791be0b5ef67063b3c10b840fb81514db2fd", out)
+
+	cfg, refs, err := readConfigFile(filepath.Join(home, "config.yaml"))
+	require.NoError(t, err)
+	keystore, password, err := keystoreFields(cfg.ProviderConfigs["icon"].Value)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, "keys", "0x2.icon", "hxb6b5791be0b5ef67063b3c10b840fb81514db2fd.json"), *keystore)
+	assert.Equal(t, "gochain", *password)
+	assert.Equal(t, "${TEST_KEYS_PASSWORD}", refs["chains.icon.value.password"].raw)
+
+	out, err = runKeys(t, home, "show", "icon")
+	require.NoError(t, err)
+	assert.Equal(t, "hxb6b5791be0b5ef67063b3c10b840fb81514db2fd", out)
+
+	out, err = runKeys(t, home, "add", "icon")
+	require.NoError(t, err)
+	added := strings.TrimPrefix(out, "icon: ")
+
+	out, err = runKeys(t, home, "list", "icon")
+	require.NoError(t, err)
+	assert.Contains(t, out, "*  "+added)
+	assert.Contains(t, out, "   hxb6b5791be0b5ef67063b3c10b840fb81514db2fd")
+
+	_, err = runKeys(t, home, "delete", "icon", added)
+	assert.ErrorContains(t, err, "is used by chain icon")
+	_, err = runKeys(t, home, "delete", "icon", "hxb6b5791be0b5ef67063b3c10b840fb81514db2fd")
+	assert.NoError(t, err)
+
+	_, err = runKeys(t, home, "add", "unknown")
+	assert.Error(t, err)
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer"
+)
+
+// PathConfig is a named route of the config with its policies, start relays only the given paths
+type PathConfig struct {
+	Src string `yaml:"src" json:"src"`
+	Dst string `yaml:"dst" json:"dst"`
+	// Bidirectional relays the messages from dst to src as well
+	Bidirectional bool `yaml:"bidirectional,omitempty" json:"bidirectional,omitempty"`
+	// TxRetry is the number of failed deliveries after which a message is stored to be flushed again
+	TxRetry uint64 `yaml:"tx-retry,omitempty" json:"tx-retry,omitempty"`
+	// FlushInterval is how often the stored messages of the path are flushed, the flush interval of start when empty
+	FlushInterval string `yaml:"flush-interval,omitempty" json:"flush-interval,omitempty"`
+}
+
+// Routes returns the directions messages of the path are relayed in with the policies of the path
+func (p *PathConfig) Routes() ([]relayer.Route, error) {
+	var flushInterval time.Duration
+	if p.FlushInterval != "" {
+		var err error
+		if flushInterval, err = time.ParseDuration(p.FlushInterval); err != nil {
+			return nil, fmt.Errorf("invalid flush-interval: %w", err)
+		}
+		if flushInterval <= 0 {
+			return nil, fmt.Errorf("flush-interval must be positive")
+		}
+	}
+	route := relayer.Route{Src: p.Src, Dst: p.Dst, TxRetry: p.TxRetry, FlushInterval: flushInterval}
+	routes := []relayer.Route{route}
+	if p.Bidirectional {
+		route.Src, route.Dst = p.Dst, p.Src
+		routes = append(routes, route)
+	}
+	return routes, nil
+}
+
+// pathProblems checks that the paths link configured chains, nIds are the chain names by nid
+func pathProblems(paths map[string]*PathConfig, nIds map[string]string) []error {
+	names := make([]string, 0, len(paths))
+	for name := range paths {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var problems []error
+	for _, name := range names {
+		p := paths[name]
+		if p == nil {
+			problems = append(problems, fmt.Errorf("path %s: src and dst are required", name))
+			continue
+		}
+		for _, end := range [][2]string{{"src", p.Src}, {"dst", p.Dst}} {
+			field, nId := end[0], end[1]
+			if nId == "" {
+				problems = append(problems, fmt.Errorf("path %s: %s is required", name, field))
+			} else if _, ok := nIds[nId]; !ok {
+				problems = append(problems, fmt.Errorf("path %s: %s %s is not the nid of a configured chain", name, field, nId))
+			}
+		}
+		if p.Src != "" && p.Src == p.Dst {
+			problems = append(problems, fmt.Errorf("path %s: src and dst must differ", name))
+		}
+		if _, err := p.Routes(); err != nil {
+			problems = append(problems, fmt.Errorf("path %s: %w", name, err))
+		}
+	}
+	return problems
+}
+
+// chainSelection is the part of the config relayed by start, everything when empty
+type chainSelection struct {
+	paths  []string
+	chains []string
+}
+
+// apply returns the chains and the routes of the selection, routes is nil when
+// every route between the chains is relayed
+func (s chainSelection) apply(cfg *Config) (map[string]*relayer.Chain, []relayer.Route, error) {
+	all := cfg.Chains.GetAll()
+	if len(s.paths) > 0 && len(s.chains) > 0 {
+		return nil, nil, fmt.Errorf("select either paths or --chains")
+	}
+
+	selected := make(map[string]*relayer.Chain)
+	add := func(nId string) error {
+		chain, ok := all[nId]
+		if !ok {
+			return fmt.Errorf("chain %s is not configured", nId)
+		}
+		selected[nId] = chain
+		return nil
+	}
+	switch {
+	case len(s.paths) > 0:
+		var routes []relayer.Route
+		for _, name := range s.paths {
+			path, ok := cfg.Paths[name]
+			if !ok || path == nil {
+				if len(cfg.Paths) == 0 {
+					return nil, nil, fmt.Errorf("path %s is not configured, the config has no paths", name)
+				}
+				return nil, nil, fmt.Errorf("path %s is not configured, the paths are: %s", name, strings.Join(cfg.pathNames(), ", "))
+			}
+			for _, nId := range []string{path.Src, path.Dst} {
+				if err := add(nId); err != nil {
+					return nil, nil, fmt.Errorf("path %s: %w", name, err)
+				}
+			}
+			pathRoutes, err := path.Routes()
+			if err != nil {
+				return nil, nil, fmt.Errorf("path %s: %w", name, err)
+			}
+			routes = append(routes, pathRoutes...)
+		}
+		return selected, routes, nil
+	case len(s.chains) > 0:
+		for _, nId := range s.chains {
+			if err := add(nId); err != nil {
+				return nil, nil, err
+			}
+		}
+		return selected, nil, nil
+	}
+	return all, nil, nil
+}
+
+// pathRoutes returns the routes of all the paths with their policies, in the order of the path names
+func (c *Config) pathRoutes() ([]relayer.Route, error) {
+	var routes []relayer.Route
+	for _, name := range c.pathNames() {
+		if c.Paths[name] == nil {
+			continue
+		}
+		pathRoutes, err := c.Paths[name].Routes()
+		if err != nil {
+			return nil, fmt.Errorf("path %s: %w", name, err)
+		}
+		routes = append(routes, pathRoutes...)
+	}
+	return routes, nil
+}
+
+func (c *Config) pathNames() []string {
+	names := make([]string, 0, len(c.Paths))
+	for name := range c.Paths {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer"
+	"github.com/icon-project/centralized-relay/relayer/chains/mockchain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestChainSelection(t *testing.T) {
+	log := zap.NewNop()
+	cfg := &Config{Chains: make(relayer.Chains), Paths: map[string]*PathConfig{
+		"a-b": {Src: "a", Dst: "b", Bidirectional: true, TxRetry: 5, FlushInterval: "1m"},
+		"b-c": {Src: "b", Dst: "c"},
+		"a-d": {Src: "a", Dst: "d"},
+	}}
+	for _, nId := range []string{"a", "b", "c"} {
+		prov, err := (&mockchain.MockProviderConfig{NId: nId}).NewProvider(log, "", false, nId)
+		require.NoError(t, err)
+		cfg.Chains[nId] = relayer.NewChain(log, prov, false)
+	}
+
+	chains, routes, err := chainSelection{}.apply(cfg)
+	require.NoError(t, err)
+	assert.Len(t, chains, 3)
+	assert.Nil(t, routes)
+
+	chains, routes, err = chainSelection{paths: []string{"a-b", "b-c"}}.apply(cfg)
+	require.NoError(t, err)
+	assert.Len(t, chains, 3)
+	assert.Equal(t, []relayer.Route{
+		{Src: "a", Dst: "b", TxRetry: 5, FlushInterval: time.Minute},
+		{Src: "b", Dst: "a", TxRetry: 5, FlushInterval: time.Minute},
+		{Src: "b", Dst: "c"},
+	}, routes)
+
+	policies, err := cfg.pathRoutes()
+	require.NoError(t, err)
+	assert.Len(t, policies, 4)
+
+	chains, routes, err = chainSelection{chains: []string{"a", "c"}}.apply(cfg)
+	require.NoError(t, err)
+	assert.Contains(t, chains, "a")
+	assert.Contains(t, chains, "c")
+	assert.NotContains(t, chains, "b")
+	assert.Nil(t, routes)
+
+	_, _, err = chainSelection{paths: []string{"x"}}.apply(cfg)
+	assert.ErrorContains(t, err, "a-b, a-d, b-c")
+	_, _, err = chainSelection{paths: []string{"a-d"}}.apply(cfg)
+	assert.Error(t, err)
+	_, _, err = chainSelection{paths: []string{"a-b"}, chains: []string{"c"}}.apply(cfg)
+	assert.Error(t, err)
+
+	problems := pathProblems(cfg.Paths, map[string]string{"a": "chain-a", "b": "chain-b", "c": "chain-c"})
+	require.Len(t, problems, 1)
+	assert.EqualError(t, problems[0], "path a-d: dst d is not the nid of a configured chain")
+	assert.Len(t, pathProblems(map[string]*PathConfig{"loop": {Src: "a", Dst: "a"}, "empty": {}}, map[string]string{"a": "chain-a"}), 3)
+	problems = pathProblems(map[string]*PathConfig{"slow": {Src: "a", Dst: "b", FlushInterval: "often"}}, map[string]string{"a": "chain-a", "b": "chain-b"})
+	require.Len(t, problems, 1)
+	assert.ErrorContains(t, problems[0], "path slow: invalid flush-interval")
+}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/spf13/cobra"
+)
+
+type pauseState struct {
+	dst      string
+	reason   string
+	duration time.Duration
+}
+
+func chainsPauseCmd(a *appState) *cobra.Command {
+	state := &pauseState{}
+	cmd := &cobra.Command{
+		Use:   "pause nid",
+		Short: "Pause relaying for a chain, or for a single route with --dst",
+		Long: strings.TrimSpace(`Pause relaying for a chain, or for a single route with --dst.
+A paused chain stops listening and no message is relayed from or to it,
+messages stay queued and are relayed once the chain or route is resumed.
+The pause is sent to the running relayer through the api, the database is updated when no relayer runs.`),
+		Args: withUsage(cobra.ExactArgs(1)),
+		Annotations: map[string]string{
+			annotationSkipDB: "true",
+		},
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s chains pause 0x2.icon --reason "contract upgrade" --for 2h
+$ %s chains pause 0x2.icon --dst 0x13881.mumbai`, appName, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pause := types.Pause{
+				Src:       args[0],
+				Dst:       state.dst,
+				Reason:    state.reason,
+				CreatedAt: time.Now().UTC(),
+			}
+			if state.duration > 0 {
+				expiresAt := pause.CreatedAt.Add(state.duration)
+				pause.ExpiresAt = &expiresAt
+			}
+			body, err := json.Marshal(pause)
+			if err != nil {
+				return err
+			}
+			pauses, err := a.pauseAPI(http.MethodPost, nil, body, func(rly *relayer.Relayer) error {
+				return rly.Pause(pause)
+			})
+			if err != nil {
+				return err
+			}
+			return printPauses(cmd.OutOrStdout(), pauses)
+		},
+	}
+	cmd.Flags().StringVar(&state.dst, "dst", "", "pause only the route to this destination nid")
+	cmd.Flags().StringVar(&state.reason, "reason", "", "reason of the pause")
+	cmd.Flags().DurationVar(&state.duration, "for", 0, "resume automatically after this duration, 0 pauses until resumed")
+	return cmd
+}
+
+func chainsResumeCmd(a *appState) *cobra.Command {
+	state := &pauseState{}
+	cmd := &cobra.Command{
+		Use:   "resume nid",
+		Short: "Resume relaying for a paused chain, or for a paused route with --dst",
+		Args:  withUsage(cobra.ExactArgs(1)),
+		Annotations: map[string]string{
+			annotationSkipDB: "true",
+		},
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s chains resume 0x2.icon
+$ %s chains resume 0x2.icon --dst 0x13881.mumbai`, appName, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			query := url.Values{"src": {args[0]}, "dst": {state.dst}}
+			pauses, err := a.pauseAPI(http.MethodDelete, query, nil, func(rly *relayer.Relayer) error {
+				return rly.Resume(args[0], state.dst)
+			})
+			if err != nil {
+				return err
+			}
+			return printPauses(cmd.OutOrStdout(), pauses)
+		},
+	}
+	cmd.Flags().StringVar(&state.dst, "dst", "", "resume only the route to this destination nid")
+	return cmd
+}
+
+func chainsPausesCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pauses",
+		Short: "List the paused chains and routes",
+		Args:  withUsage(cobra.NoArgs),
+		Annotations: map[string]string{
+			annotationSkipDB: "true",
+		},
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s chains pauses`, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pauses, err := a.pauseAPI(http.MethodGet, nil, nil, nil)
+			if err != nil {
+				return err
+			}
+			return printPauses(cmd.OutOrStdout(), pauses)
+		},
+	}
+	return cmd
+}
+
+// pauseAPI calls /pauses on the running relayer, when no relayer listens
+// the change is applied to the database which the relayer reads on start
+func (a *appState) pauseAPI(method string, query url.Values, body []byte, offline func(*relayer.Relayer) error) ([]*types.Pause, error) {
+	var pauses []*types.Pause
+	err := a.callAPI(method, "/pauses", query, body, &pauses)
+	if err == nil || !apiUnreachable(err) {
+		return pauses, err
+	}
+
+	rly, err := a.offlineRelayer()
+	if err != nil {
+		return nil, err
+	}
+	if offline != nil {
+		if err := offline(rly); err != nil {
+			return nil, err
+		}
+	}
+	return rly.Pauses(), nil
+}
+
+func printPauses(out io.Writer, pauses []*types.Pause) error {
+	if len(pauses) == 0 {
+		fmt.Fprintln(out, "no chain or route is paused")
+		return nil
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SRC\tDST\tREASON\tSINCE\tEXPIRES")
+	for _, p := range pauses {
+		dst, expires := p.Dst, "never"
+		if dst == "" {
+			dst = "*"
+		}
+		if p.ExpiresAt != nil {
+			expires = p.ExpiresAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", p.Src, dst, p.Reason, p.CreatedAt.Format(time.RFC3339), expires)
+	}
+	return w.Flush()
+}
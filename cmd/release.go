@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/icon-project/centralized-relay/relayer"
+	"github.com/spf13/cobra"
+)
+
+func (d *dbState) messagesRelease(app *appState) *cobra.Command {
+	release := &cobra.Command{
+		Use:   "release",
+		Short: "Verify a quarantined message again and relay it once it passes",
+		Long: strings.TrimSpace(`Verify a quarantined message again and relay it once it passes.
+The block of the message is scanned again like the listener does, a message passing the verification
+leaves the quarantine and is queued unless its destination already received it.
+The message is released in the running relayer through the api, in the database when no relayer runs.`),
+		Args: withUsage(cobra.NoArgs),
+		Annotations: map[string]string{
+			annotationSkipDB: "true",
+		},
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s db messages list --chain 0x2.icon --quarantined
+$ %s db messages release --chain 0x2.icon --sn 120`, appName, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsn, err := cmd.Flags().GetBool(flagJSON)
+			if err != nil {
+				return err
+			}
+			opts := relayer.ReleaseOptions{Chain: d.chain, Sn: d.sn}
+			body, err := json.Marshal(opts)
+			if err != nil {
+				return err
+			}
+
+			var released *relayer.InjectedMessage
+			err = app.callAPI(http.MethodPost, "/quarantine/release", nil, body, &released)
+			if apiUnreachable(err) {
+				rly, rlyErr := app.offlineRelayer()
+				if rlyErr != nil {
+					return rlyErr
+				}
+				released, err = rly.ReleaseQuarantined(cmd.Context(), opts)
+			}
+			if err != nil {
+				return err
+			}
+
+			if jsn {
+				out, err := json.Marshal(released)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), string(out))
+				return nil
+			}
+			return printInjected(cmd.OutOrStdout(), []*relayer.InjectedMessage{released})
+		},
+	}
+	d.messageChainFlag(release)
+	d.messageMsgIDFlag(release)
+	return jsonFlag(app.viper, release)
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/icon-project/centralized-relay/relayer"
+	"go.uber.org/zap"
+)
+
+// configWatchDebounce groups the burst of events produced by a single write of the config file
+const configWatchDebounce = time.Second
+
+// configReloader applies the chains of the config file to the running relayer
+type configReloader struct {
+	a       *appState
+	relayer *relayer.Relayer
+	// ctx is the context of the relayer, the started chains run until it is done
+	ctx context.Context
+	// selection is the part of the config given to start, the chains left out stay stopped on reload
+	selection chainSelection
+	mu        sync.Mutex
+}
+
+// Reload reads the config file again and starts or stops the chains that changed,
+// the running chains are left untouched if the config file is invalid
+func (c *configReloader) Reload() (relayer.ReloadResult, error) {
+	ctx := c.ctx
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cfgWrapper, refs, err := readConfigFile(c.a.configPath)
+	if err != nil {
+		return relayer.ReloadResult{}, err
+	}
+	if problems := cfgWrapper.Problems(); len(problems) > 0 {
+		return relayer.ReloadResult{}, fmt.Errorf("invalid config: %w", errors.Join(problems...))
+	}
+	newCfg, err := cfgWrapper.RuntimeConfig(ctx, c.a)
+	if err != nil {
+		return relayer.ReloadResult{}, err
+	}
+	newCfg.refs = refs
+	chains, _, err := c.selection.apply(newCfg)
+	if err != nil {
+		return relayer.ReloadResult{}, err
+	}
+
+	result, err := c.relayer.Reload(ctx, chains)
+	if err != nil {
+		return result, err
+	}
+	// only the chains are reloaded, the global settings apply on restart
+	newCfg.Global = c.a.config.Global
+	c.a.config = newCfg
+	return result, nil
+}
+
+func (c *configReloader) reloadAndLog(trigger string) {
+	result, err := c.Reload()
+	if err != nil {
+		c.a.log.Error("failed to reload config", zap.String("trigger", trigger), zap.Error(err))
+		return
+	}
+	c.a.log.Info("config reloaded",
+		zap.String("trigger", trigger),
+		zap.Strings("added", result.Added),
+		zap.Strings("removed", result.Removed),
+		zap.Strings("restarted", result.Restarted),
+	)
+}
+
+// HandleSignals reloads the config on SIGHUP until the relayer stops
+func (c *configReloader) HandleSignals() {
+	ctx := c.ctx
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	defer signal.Stop(sighup)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sighup:
+			c.reloadAndLog("sighup")
+		}
+	}
+}
+
+// Watch reloads the config whenever the config file is written until the relayer stops.
+// The directory is watched as editors and config tools replace the file instead of writing it in place
+func (c *configReloader) Watch() error {
+	ctx := c.ctx
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	cfgPath := filepath.Clean(c.a.configPath)
+	if err := watcher.Add(filepath.Dir(cfgPath)); err != nil {
+		watcher.Close()
+		return err
+	}
+
+	go func() {
+		defer watcher.Close()
+		var debounce <-chan time.Time
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != cfgPath || event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+					continue
+				}
+				debounce = time.After(configWatchDebounce)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				c.a.log.Warn("config watcher error", zap.Error(err))
+			case <-debounce:
+				debounce = nil
+				c.reloadAndLog("watch")
+			}
+		}
+	}()
+	return nil
+}
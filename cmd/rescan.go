@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer"
+	"github.com/spf13/cobra"
+)
+
+func rescanCmd(a *appState) *cobra.Command {
+	opts := relayer.RescanOptions{}
+	var timeout time.Duration
+	cmd := &cobra.Command{
+		Use:   "rescan",
+		Short: "Scan a block range of a chain again and relay the messages their destination did not receive",
+		Long: strings.TrimSpace(`Scan a block range of a chain again and relay the messages their destination did not receive.
+The messages are parsed from the blocks like the listener does, the ones already received or being
+relayed are left out. The height stored for the listener is not moved.
+The rescan runs in the running relayer through the api, on the database when no relayer runs.`),
+		Args: withUsage(cobra.NoArgs),
+		Annotations: map[string]string{
+			annotationSkipDB: "true",
+		},
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s rescan --chain 0x2.icon --from 1000 --to 1200
+$ %s rescan -c 0x13881.mumbai --from 4500000 --to 4510000 --json`, appName, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsn, err := cmd.Flags().GetBool(flagJSON)
+			if err != nil {
+				return err
+			}
+			if opts.From > opts.To {
+				return fmt.Errorf("--from %d is after --to %d", opts.From, opts.To)
+			}
+			body, err := json.Marshal(opts)
+			if err != nil {
+				return err
+			}
+
+			var report *relayer.RescanReport
+			err = a.callAPITimeout(timeout, http.MethodPost, "/rescan", nil, body, &report)
+			if apiUnreachable(err) {
+				rly, rlyErr := a.offlineRelayer()
+				if rlyErr != nil {
+					return rlyErr
+				}
+				report, err = rly.Rescan(cmd.Context(), opts)
+			}
+			if err != nil {
+				return err
+			}
+
+			if jsn {
+				out, err := json.Marshal(report)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), string(out))
+				return nil
+			}
+			return printRescanReport(cmd.OutOrStdout(), report)
+		},
+	}
+	cmd.Flags().StringVarP(&opts.Chain, "chain", "c", "", "nid of the chain to rescan")
+	cmd.Flags().Uint64Var(&opts.From, "from", 0, "first block height to rescan")
+	cmd.Flags().Uint64Var(&opts.To, "to", 0, "last block height to rescan")
+	cmd.Flags().DurationVar(&timeout, "timeout", defaultScanTimeout, "maximum wait for the rescan of the running relayer")
+	for _, flag := range []string{"chain", "from", "to"} {
+		if err := cmd.MarkFlagRequired(flag); err != nil {
+			panic(err)
+		}
+	}
+	return jsonFlag(a.viper, cmd)
+}
+
+func printRescanReport(out io.Writer, report *relayer.RescanReport) error {
+	var received uint64
+	for _, n := range report.Received {
+		received += n
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CHAIN\tFROM\tTO\tFOUND\tRECEIVED\tPENDING\tUNROUTABLE\tQUARANTINED\tQUEUED")
+	fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\t%d\t%d\t%d\n", report.Chain, report.From, report.To,
+		report.Found, received, report.Pending, report.Unroutable, report.Quarantined, len(report.Undelivered))
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if len(report.Undelivered) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(out)
+	w = tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SRC\tDST\tSN\tHEIGHT\tQUEUED\tERROR")
+	for _, gap := range report.Undelivered {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%t\t%s\n", gap.Src, gap.Dst, gap.Sn, gap.Height, gap.Requeued, gap.Error)
+	}
+	return w.Flush()
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"path/filepath"
@@ -11,7 +12,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/icon-project/centralized-relay/relayer/lvldb"
 	zaplogfmt "github.com/jsternberg/zap-logfmt"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -21,6 +21,13 @@ import (
 
 const appName = "centralized-relay"
 
+const (
+	// annotationSkipConfig marks the commands that run without the database and the loaded config
+	annotationSkipConfig = "skip-config"
+	// annotationSkipDB marks the commands that run without the database, so they work next to a running relayer
+	annotationSkipDB = "skip-db"
+)
+
 var (
 	defaultHome   = filepath.Join(os.Getenv("HOME"), ".centralized-relay")
 	defaultDBName = "data"
@@ -101,8 +108,12 @@ func NewRootCmd(log *zap.Logger) *cobra.Command {
 			a.log = log
 		}
 
-		if a.db == nil {
-			db, err := lvldb.NewLvlDB(a.dbPath, false)
+		if cmd.Annotations[annotationSkipConfig] == "true" {
+			return nil
+		}
+
+		if a.db == nil && cmd.Annotations[annotationSkipDB] != "true" {
+			db, err := a.openDB()
 			if err != nil {
 				return fmt.Errorf("error while creating db %v", err)
 			}
@@ -120,8 +131,8 @@ func NewRootCmd(log *zap.Logger) *cobra.Command {
 		// Force syncing the logs before exit, if anything is buffered.
 		_ = a.log.Sync()
 
-		if a.db != nil {
-			a.db.Close()
+		if closer, ok := a.db.(io.Closer); ok {
+			closer.Close()
 		}
 	}
 
@@ -152,12 +163,21 @@ func NewRootCmd(log *zap.Logger) *cobra.Command {
 		panic(err)
 	}
 
+	rootCmd.PersistentFlags().StringVar(&a.dbBackend, "db-backend", dbBackendLevelDB, "database backend (leveldb or memory), memory holds all the data in RAM and loses it on exit")
+	if err := a.viper.BindPFlag("db-backend", rootCmd.PersistentFlags().Lookup("db-backend")); err != nil {
+		panic(err)
+	}
+
 	// Register subcommands
 	rootCmd.AddCommand(
 		startCmd(a),
 		configCmd(a),
 		chainsCmd(a),
 		dbCmd(a),
+		keysCmd(a),
+		signerCmd(a),
+		auditCmd(a),
+		rescanCmd(a),
 	)
 	return rootCmd
 }
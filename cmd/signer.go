@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/signer"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+func signerCmd(a *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "signer",
+		Short: "Run the reference signing service of the remote signer",
+	}
+	cmd.AddCommand(signerServeCmd(a))
+	return cmd
+}
+
+type signerServeState struct {
+	listen   string
+	keys     []string
+	password string
+	token    string
+	tlsCert  string
+	tlsKey   string
+}
+
+func signerServeCmd(a *appState) *cobra.Command {
+	state := &signerServeState{}
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve the keys of keystores to relayers configured with a remote signer",
+		Long: strings.TrimSpace(`Serve the keys of keystores to relayers configured with a remote signer.
+Run it on a host apart from the relayer so the keys are never loaded in the relayer process,
+the chain config points to it with:
+
+  signer:
+    type: remote
+    remote:
+      url: https://signer.internal:5184
+      key-id: avalanche
+      token: ${SIGNER_TOKEN}`),
+		Args: withUsage(cobra.NoArgs),
+		Annotations: map[string]string{
+			annotationSkipConfig: "true",
+		},
+		Example: strings.TrimSpace(fmt.Sprintf(`
+$ %s signer serve --key avalanche=evm:keys/0xa869.fuji/0x1304...json --key icon=icon:keystore.json \
+    --password '${SIGNER_KEYSTORE_PASSWORD}' --token 'file:///run/secrets/signer-token'`, appName)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keys, err := state.loadKeys()
+			if err != nil {
+				return err
+			}
+			token, err := resolveRef(state.token)
+			if err != nil {
+				return fmt.Errorf("token: %w", err)
+			}
+			if token == "" {
+				a.log.Warn("serving keys without a token, any client reaching the signer can sign")
+			}
+			return state.serve(cmd.Context(), a.log, signer.NewServer(a.log, keys, token))
+		},
+	}
+	cmd.Flags().StringVar(&state.listen, "listen", "127.0.0.1:5184", "address of the signing service")
+	cmd.Flags().StringArrayVar(&state.keys, "key", nil, "key to serve as id=chain-type:keystore-path, can be repeated")
+	cmd.Flags().StringVar(&state.password, "password", "", "password of the keystores, ${ENV_VAR} and file:// references are resolved")
+	cmd.Flags().StringVar(&state.token, "token", "", "bearer token required from the clients, ${ENV_VAR} and file:// references are resolved")
+	cmd.Flags().StringVar(&state.tlsCert, "tls-cert", "", "tls certificate file, the service uses https when set")
+	cmd.Flags().StringVar(&state.tlsKey, "tls-key", "", "tls key file")
+	return cmd
+}
+
+// loadKeys decrypts the keystores of the --key flags
+func (s *signerServeState) loadKeys() (map[string]signer.Signer, error) {
+	if len(s.keys) == 0 {
+		return nil, errors.New("at least one --key is required")
+	}
+	password, err := resolveRef(s.password)
+	if err != nil {
+		return nil, fmt.Errorf("password: %w", err)
+	}
+
+	keys := make(map[string]signer.Signer, len(s.keys))
+	for _, key := range s.keys {
+		id, source, ok := strings.Cut(key, "=")
+		chainType, path, typed := strings.Cut(source, ":")
+		if !ok || !typed || id == "" || path == "" {
+			return nil, fmt.Errorf("invalid key %q, expected id=chain-type:keystore-path", key)
+		}
+		if _, ok := keys[id]; ok {
+			return nil, fmt.Errorf("key %s is given twice", id)
+		}
+		codec, ok := keystoreCodecs[chainType]
+		if !ok {
+			return nil, fmt.Errorf("key %s: chain type %s has no keystore", id, chainType)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", id, err)
+		}
+		_, privateKey, err := codec.decrypt(data, password)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: failed to decrypt %s: %w", id, path, err)
+		}
+		if keys[id], err = signer.NewLocal(privateKey); err != nil {
+			return nil, fmt.Errorf("key %s: %w", id, err)
+		}
+	}
+	return keys, nil
+}
+
+// serve listens until ctx is done
+func (s *signerServeState) serve(ctx context.Context, log *zap.Logger, handler http.Handler) error {
+	if (s.tlsCert == "") != (s.tlsKey == "") {
+		return errors.New("--tls-cert and --tls-key must be set together")
+	}
+	srv := &http.Server{Addr: s.listen, Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		srv.Close()
+	}()
+
+	log.Info("serving signer", zap.String("addr", s.listen), zap.Int("keys", len(s.keys)), zap.Bool("tls", s.tlsCert != ""))
+	var err error
+	if s.tlsCert != "" {
+		err = srv.ListenAndServeTLS(s.tlsCert, s.tlsKey)
+	} else {
+		err = srv.ListenAndServe()
+	}
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/icon-project/centralized-relay/relayer"
 	"github.com/spf13/cobra"
@@ -13,18 +14,27 @@ import (
 
 // startCmd represents the start command
 func startCmd(a *appState) *cobra.Command {
+	selection := chainSelection{}
 	cmd := &cobra.Command{
-		Use:     "start path_name",
+		Use:     "start [path...]",
 		Aliases: []string{"st"},
-		Short:   "Start the listening relayer on a given path",
-		Args:    withUsage(cobra.MinimumNArgs(0)),
+		Short:   "Start the listening relayer on the given paths, on every chain without them",
+		Long: strings.TrimSpace(`Start the listening relayer on the given paths, on every chain without them.
+Paths are named routes of the paths section of the config, only their chains are started and only
+their messages are relayed. --chains starts a subset of the chains and relays every route between them.
+Messages to the chains or routes left out are kept in the database, a config can be split across relayers.
+The tx-retry and flush-interval of a path apply to its routes whenever they are relayed.`),
+		Args: withUsage(cobra.ArbitraryArgs),
 		Example: strings.TrimSpace(fmt.Sprintf(`
-			$ %s start # start all the registered chains
-		`, appName)),
+$ %s start # start all the registered chains
+$ %s start icon-mumbai icon-fuji
+$ %s start --chains 0x2.icon,0x13881.mumbai`, appName, appName, appName)),
 		RunE: func(cmd *cobra.Command, args []string) error {
-
-			// get chain configurations
-			chains := a.config.Chains.GetAll()
+			selection.paths = args
+			chains, routes, err := selection.apply(a.config)
+			if err != nil {
+				return err
+			}
 
 			flushInterval, err := cmd.Flags().GetDuration(flagFlushInterval)
 			if err != nil {
@@ -36,18 +46,94 @@ func startCmd(a *appState) *cobra.Command {
 				return err
 			}
 
+			if err := a.startBackupJob(cmd.Context()); err != nil {
+				return err
+			}
+
+			db, err := a.dataStore()
+			if err != nil {
+				return err
+			}
+
+			reloader := &configReloader{a: a, ctx: cmd.Context(), selection: selection}
+			opts := []relayer.Option{func(ctx context.Context, r *relayer.Relayer) {
+				reloader.relayer = r
+			}}
+			if routes != nil {
+				opts = append(opts, relayer.WithRoutes(routes...))
+			} else {
+				// the policies of the paths apply when the chains are relayed as a whole as well
+				policies, err := a.config.pathRoutes()
+				if err != nil {
+					return err
+				}
+				opts = append(opts, relayer.WithRoutePolicies(policies...))
+			}
+			if monitor := a.config.Global.BalanceMonitor; monitor != nil {
+				cfg, err := monitor.RuntimeConfig()
+				if err != nil {
+					return err
+				}
+				opts = append(opts, relayer.WithBalanceMonitor(cfg))
+			}
+			if audit := a.config.Global.Audit; audit != nil {
+				cfg, err := audit.RuntimeConfig()
+				if err != nil {
+					return err
+				}
+				opts = append(opts, relayer.WithAudit(cfg))
+			}
+			if ha := a.config.Global.HA; ha != nil {
+				cfg, err := ha.RuntimeConfig()
+				if err != nil {
+					return err
+				}
+				a.log.Info("starting as a standby until the leadership lease is acquired", zap.String("id", cfg.ID))
+				opts = append(opts, relayer.WithLeaderElection(cfg))
+			}
+			if a.config.Global.ZeroKeysOnShutdown {
+				opts = append(opts, relayer.WithKeyZeroing())
+			}
+
 			rlyErrCh, err := relayer.Start(
 				cmd.Context(),
 				a.log,
 				chains,
 				flushInterval,
 				fresh,
-				a.db,
+				db,
+				opts...,
 			)
 			if err != nil {
 				return err
 			}
 
+			// the chain config is reloaded on SIGHUP, on POST /reload and when the config file changes
+			go reloader.HandleSignals()
+			if watch, _ := cmd.Flags().GetBool(flagWatchConfig); watch {
+				if err := reloader.Watch(); err != nil {
+					a.log.Warn("not watching the config file", zap.Error(err))
+				}
+			}
+			if addr := a.config.Global.APIListenPort; addr != "" {
+				token, err := a.config.Global.apiToken()
+				if err != nil {
+					return err
+				}
+				if token == "" {
+					a.log.Warn("no admin api token is configured, the admin api is read only", zap.String("env", defaultAPITokenEnv))
+				}
+				api := newAPIServer(a.log, token)
+				api.handleReload(reloader)
+				api.handlePauses(reloader.relayer)
+				api.handleStatus(reloader.relayer)
+				api.handleAudit(reloader.relayer)
+				api.handleRescan(reloader.relayer)
+				api.handleInject(reloader.relayer)
+				api.handleRelease(reloader.relayer)
+				go api.Serve(cmd.Context(), addr)
+			}
+
 			// Block until the error channel sends a message.
 			// The context being canceled will cause the relayer to stop,
 			// so we don't want to separately monitor the ctx.Done channel,
@@ -61,5 +147,43 @@ func startCmd(a *appState) *cobra.Command {
 	}
 	cmd = flushIntervalFlag(a.viper, cmd)
 	cmd = freshFlag(a.viper, cmd)
+	cmd = watchConfigFlag(a.viper, cmd)
+	cmd.Flags().StringSliceVar(&selection.chains, "chains", nil, "nid of the chains to start, all the chains when not given")
 	return cmd
 }
+
+// startBackupJob periodically backs up the database when backup-dir and backup-interval are configured
+func (a *appState) startBackupJob(ctx context.Context) error {
+	global := a.config.Global
+	if global == nil || global.BackupDir == "" || global.BackupInterval == "" {
+		return nil
+	}
+	interval, err := time.ParseDuration(global.BackupInterval)
+	if err != nil {
+		return fmt.Errorf("invalid backup-interval: %w", err)
+	}
+	db, err := a.levelDB()
+	if err != nil {
+		a.log.Warn("skipping database backups", zap.Error(err))
+		return nil
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				path, err := db.Backup(global.BackupDir, global.BackupKeep)
+				if err != nil {
+					a.log.Error("database backup failed", zap.Error(err))
+					continue
+				}
+				a.log.Info("database backup completed", zap.String("path", path))
+			}
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,388 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/events"
+	"github.com/icon-project/centralized-relay/relayer/metrics"
+	"github.com/icon-project/centralized-relay/relayer/provider"
+	"github.com/icon-project/centralized-relay/relayer/store"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"go.uber.org/zap"
+)
+
+const (
+	DefaultAuditInterval = 10 * time.Minute
+	// DefaultAuditRange bounds the sn walked per source chain by an audit
+	DefaultAuditRange = 1000
+	// AuditAttempts is the number of audits a sn which cannot be resolved holds the checkpoint back,
+	// the audits after it report it and go on with the following sn
+	AuditAttempts = 3
+)
+
+// AuditConfig configures the reconciliation job
+type AuditConfig struct {
+	Interval time.Duration
+	// Requeue relays the missing messages again, they are only reported otherwise
+	Requeue bool
+	// MaxRange is the number of sn walked per source chain and run
+	MaxRange uint64
+}
+
+// AuditOptions selects what an audit walks
+type AuditOptions struct {
+	// Src are the source chains, all the chains when empty
+	Src []string `json:"src,omitempty"`
+	// From and To bound the sn walked, From defaults to the sn after the last audit
+	// and To to the sn of the connection at the height processed by the listener
+	From    uint64 `json:"from,omitempty"`
+	To      uint64 `json:"to,omitempty"`
+	Requeue bool   `json:"requeue,omitempty"`
+	// MaxRange bounds the sn walked when From is not given
+	MaxRange uint64 `json:"maxRange,omitempty"`
+}
+
+// Gap is a message of a source connection which its destination did not receive
+type Gap struct {
+	Src      string `json:"src"`
+	Dst      string `json:"dst,omitempty"`
+	Sn       uint64 `json:"sn"`
+	Height   uint64 `json:"height,omitempty"`
+	Requeued bool   `json:"requeued"`
+	Error    string `json:"error,omitempty"`
+}
+
+// AuditReport is the reconciliation of the sn of a source chain with its destinations
+type AuditReport struct {
+	Src  string `json:"src"`
+	From uint64 `json:"from"`
+	To   uint64 `json:"to"`
+	// Received counts the messages received by every destination
+	Received map[string]uint64 `json:"received"`
+	// Pending counts the messages the relayer is already relaying
+	Pending uint64 `json:"pending"`
+	// Unroutable counts the messages which route is not relayed, they are parked with Requeue
+	Unroutable uint64 `json:"unroutable"`
+	Gaps       []Gap  `json:"gaps,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// WithAudit starts the reconciliation job along with the relayer
+func WithAudit(cfg AuditConfig) Option {
+	return func(ctx context.Context, r *Relayer) {
+		go r.StartAudit(ctx, cfg)
+	}
+}
+
+// StartAudit reconciles the sn of every chain with its destinations until ctx is done
+func (r *Relayer) StartAudit(ctx context.Context, cfg AuditConfig) {
+	if cfg.Interval == 0 {
+		cfg.Interval = DefaultAuditInterval
+	}
+	log := r.log.With(zap.String("component", "audit"))
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		reports, err := r.Audit(ctx, AuditOptions{Requeue: cfg.Requeue, MaxRange: cfg.MaxRange})
+		if err != nil {
+			log.Error("audit failed", zap.Error(err))
+			continue
+		}
+		for _, report := range reports {
+			logAuditReport(log, report)
+		}
+	}
+}
+
+func logAuditReport(log *zap.Logger, report *AuditReport) {
+	if report.Error != "" {
+		log.Error("failed to audit chain", zap.String("src", report.Src), zap.String("error", report.Error))
+		return
+	}
+	for _, gap := range report.Gaps {
+		log.Warn("message missing on destination",
+			zap.String("src", gap.Src),
+			zap.String("dst", gap.Dst),
+			zap.Uint64("sn", gap.Sn),
+			zap.Uint64("height", gap.Height),
+			zap.Bool("requeued", gap.Requeued),
+			zap.String("error", gap.Error),
+		)
+	}
+	log.Info("audited chain",
+		zap.String("src", report.Src),
+		zap.Uint64("from", report.From),
+		zap.Uint64("to", report.To),
+		zap.Int("gaps", len(report.Gaps)),
+	)
+}
+
+// Audit walks the sn of the source connections, every sn must be received by one of the
+// destinations, be relayed or target a chain which is not configured. The others are gaps,
+// they are generated again from the source chain and queued when Requeue is set
+func (r *Relayer) Audit(ctx context.Context, opts AuditOptions) ([]*AuditReport, error) {
+	var sources []*ChainRuntime
+	if len(opts.Src) == 0 {
+		sources = r.chainRuntimes()
+	}
+	for _, nId := range opts.Src {
+		chainRuntime, err := r.FindChainRuntime(nId)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, chainRuntime)
+	}
+	if opts.MaxRange == 0 {
+		opts.MaxRange = DefaultAuditRange
+	}
+
+	reports := make([]*AuditReport, 0, len(sources))
+	for _, src := range sources {
+		report := &AuditReport{Src: src.Provider.NID(), Received: make(map[string]uint64)}
+		if err := r.audit(ctx, src, opts, report); err != nil {
+			report.Error = err.Error()
+		}
+		reports = append(reports, report)
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Src < reports[j].Src
+	})
+	return reports, nil
+}
+
+func (r *Relayer) audit(ctx context.Context, src *ChainRuntime, opts AuditOptions, report *AuditReport) error {
+	nId := src.Provider.NID()
+	querier, ok := src.Provider.(provider.SnQuerier)
+	if !ok {
+		return fmt.Errorf("chain type %s does not expose the connection sn", src.Provider.Type())
+	}
+
+	// messages after the height processed by the listener are not missing yet
+	latest, err := src.Provider.QueryLatestHeight(ctx)
+	if err != nil {
+		return fmt.Errorf("latest height: %w", err)
+	}
+	if latest < 2 {
+		return nil
+	}
+	height := max(src.LastBlockHeight, src.LastSavedHeight)
+	if height == 0 || height >= latest {
+		height = latest - 1
+	}
+	to, err := querier.QueryConnSn(ctx, height)
+	if err != nil {
+		return err
+	}
+	if opts.To > 0 {
+		to = min(opts.To, to)
+	}
+
+	checkpoint := opts.From == 0
+	from := max(opts.From, 1)
+	if checkpoint {
+		// the walk goes on from the last audit, the first one starts MaxRange sn back
+		audited, err := r.auditStore.GetLastStoredBlock(nId)
+		switch {
+		case err == nil:
+			from = audited + 1
+			to = min(to, audited+opts.MaxRange)
+		case !store.IsNotFound(err):
+			return fmt.Errorf("last audited sn: %w", err)
+		case to > opts.MaxRange:
+			from = to - opts.MaxRange + 1
+		}
+	}
+	report.From, report.To = from, to
+
+	pending, err := r.pendingSn(src)
+	if err != nil {
+		return err
+	}
+	// the checkpoint held back by the previous audits is moved past the sn which cannot be resolved
+	var attempts uint64
+	if checkpoint {
+		if attempts, err = r.auditAttemptsStore.GetLastStoredBlock(nId); err != nil && !store.IsNotFound(err) {
+			return fmt.Errorf("audit attempts: %w", err)
+		}
+	}
+	giveUp := attempts+1 >= AuditAttempts
+
+	// audited is the last sn before the first one which must be audited again, the next run starts after it
+	audited, resolved := from-1, true
+	for sn := from; sn <= to; sn++ {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		var done bool
+		if done, err = r.auditSn(ctx, src, querier, sn, height, pending, opts.Requeue, report); err != nil {
+			break
+		}
+		if !done && checkpoint && giveUp {
+			gap := &report.Gaps[len(report.Gaps)-1]
+			gap.Error = fmt.Sprintf("%s, given up after %d audits", gap.Error, AuditAttempts)
+			done = true
+		}
+		if resolved = resolved && done; resolved {
+			audited = sn
+		}
+	}
+	stuck := !resolved && audited < from
+	if storeErr := r.storeAudited(checkpoint, nId, audited, attempts, stuck); err == nil {
+		err = storeErr
+	}
+	return err
+}
+
+// storeAudited moves the checkpoint to sn, the audits stuck on a sn which cannot be resolved are counted
+func (r *Relayer) storeAudited(checkpoint bool, nId string, sn, attempts uint64, stuck bool) error {
+	if !checkpoint {
+		return nil
+	}
+	if stuck {
+		attempts++
+	} else {
+		attempts = 0
+	}
+	if err := r.auditAttemptsStore.StoreBlock(attempts, nId); err != nil {
+		return fmt.Errorf("failed to store audit attempts: %w", err)
+	}
+	if sn == 0 {
+		return nil
+	}
+	if err := r.auditStore.StoreBlock(sn, nId); err != nil {
+		return fmt.Errorf("failed to store last audited sn: %w", err)
+	}
+	return nil
+}
+
+// auditSn checks a sn of the source chain, it returns false when the sn must be audited again.
+// height is a height of the source chain at which the connection emitted sn
+func (r *Relayer) auditSn(ctx context.Context, src *ChainRuntime, querier provider.SnQuerier, sn, height uint64, pending map[uint64]bool, requeue bool, report *AuditReport) (bool, error) {
+	nId := src.Provider.NID()
+	if pending[sn] {
+		report.Pending++
+		return true, nil
+	}
+	for _, dst := range r.chainRuntimes() {
+		if dst == src {
+			continue
+		}
+		received, err := dst.Provider.MessageReceived(ctx, types.MessageKey{Src: nId, Sn: sn, Dst: dst.Provider.NID(), EventType: events.EmitMessage})
+		if err != nil {
+			return false, fmt.Errorf("receipt of sn %d on %s: %w", sn, dst.Provider.NID(), err)
+		}
+		if received {
+			report.Received[dst.Provider.NID()]++
+			return true, nil
+		}
+	}
+
+	gap := Gap{Src: nId, Sn: sn}
+	height, err := messageHeight(ctx, querier, sn, height)
+	if err != nil {
+		gap.Error = fmt.Sprintf("failed to locate the message: %v", err)
+		report.Gaps = append(report.Gaps, gap)
+		return false, nil
+	}
+	gap.Height = height
+	key := types.MessageKey{Src: nId, Sn: sn, EventType: events.EmitMessage}
+	message, err := src.Provider.GenerateMessage(ctx, types.NewMessagekeyWithMessageHeight(key, height))
+	if err == nil && message == nil {
+		err = fmt.Errorf("chain type %s cannot generate messages", src.Provider.Type())
+	}
+	if err != nil {
+		gap.Error = err.Error()
+		report.Gaps = append(report.Gaps, gap)
+		return false, nil
+	}
+	gap.Dst = message.Dst
+	if reason := r.unroutableReason(nId, message.Dst); reason != "" {
+		report.Unroutable++
+		if requeue {
+			r.parkUnroutable(types.NewRouteMessage(message), src, reason)
+		}
+		return true, nil
+	}
+
+	metrics.MessageGaps.WithLabelValues(nId, message.Dst).Inc()
+	report.Gaps = append(report.Gaps, gap)
+	if !requeue {
+		return true, nil
+	}
+	if err := r.queueMessage(src, message); err != nil {
+		report.Gaps[len(report.Gaps)-1].Error = fmt.Sprintf("failed to queue the message: %v", err)
+		return false, nil
+	}
+	report.Gaps[len(report.Gaps)-1].Requeued = true
+	return true, nil
+}
+
+// queueMessage queues a message of src to be relayed, the store keeps it for the router
+// of a running relayer to flush or for the relayer to load on start
+func (r *Relayer) queueMessage(src *ChainRuntime, message *types.Message) error {
+	routeMessage := types.NewRouteMessage(message)
+	if err := r.messageStore.StoreMessage(routeMessage); err != nil {
+		return err
+	}
+	src.MessageCache.Add(routeMessage)
+	return nil
+}
+
+// pendingSn returns the sn of the messages of the chain being relayed or stored for a retry
+func (r *Relayer) pendingSn(src *ChainRuntime) (map[uint64]bool, error) {
+	nId := src.Provider.NID()
+	pending := make(map[uint64]bool)
+	src.MessageCache.Lock()
+	for key := range src.MessageCache.Messages {
+		pending[key.Sn] = true
+	}
+	src.MessageCache.Unlock()
+
+	stored, err := r.messageStore.GetMessages(nId, store.NewPagination().GetAll())
+	if err != nil {
+		return nil, fmt.Errorf("stored messages: %w", err)
+	}
+	for _, m := range stored {
+		pending[m.Sn] = true
+	}
+	return pending, nil
+}
+
+// messageHeight returns the height of the block which emitted sn, the sn of the connection
+// at hi must be at least sn. The search gallops back from hi as missing messages are usually recent
+func messageHeight(ctx context.Context, querier provider.SnQuerier, sn, hi uint64) (uint64, error) {
+	// lo is below the message, 0 when no height below it was found
+	var lo uint64
+	for step := uint64(1); step < hi; step *= 2 {
+		connSn, err := querier.QueryConnSn(ctx, hi-step)
+		if err != nil {
+			return 0, err
+		}
+		if connSn < sn {
+			lo = hi - step
+			break
+		}
+		hi -= step
+	}
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		connSn, err := querier.QueryConnSn(ctx, mid)
+		if err != nil {
+			return 0, err
+		}
+		if connSn < sn {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return hi, nil
+}
@@ -0,0 +1,233 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/events"
+	"github.com/icon-project/centralized-relay/relayer/memdb"
+	"github.com/icon-project/centralized-relay/relayer/provider"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// snProvider is a connection emitting messages[i] with sn i+1 and receiving the sn of received
+type snProvider struct {
+	provider.ChainProvider
+	nId      string
+	latest   uint64
+	messages []*types.Message
+	received map[uint64]bool
+	// txs are the heights of the transactions by hash, a transaction emits the messages of its height
+	txs      map[string]uint64
+	routeErr error
+	// late holds back the result of a routed message until a response is sent
+	late chan types.TxResponse
+	// generateErr fails the generation of every message, as a pruned node does
+	generateErr error
+}
+
+func (p *snProvider) QueryLatestHeight(ctx context.Context) (uint64, error) {
+	return p.latest, nil
+}
+
+func (p *snProvider) QueryConnSn(ctx context.Context, height uint64) (uint64, error) {
+	if height == 0 {
+		height = p.latest
+	}
+	var sn uint64
+	for _, m := range p.messages {
+		if m.MessageHeight <= height {
+			sn = m.Sn
+		}
+	}
+	return sn, nil
+}
+
+func (p *snProvider) GenerateMessage(ctx context.Context, key *types.MessageKeyWithMessageHeight) (*types.Message, error) {
+	if p.generateErr != nil {
+		return nil, p.generateErr
+	}
+	for _, m := range p.messages {
+		if m.Sn == key.Sn && m.MessageHeight == key.MsgHeight {
+			return m, nil
+		}
+	}
+	return nil, fmt.Errorf("sn %d not emitted at height %d", key.Sn, key.MsgHeight)
+}
+
+func (p *snProvider) MessageReceived(ctx context.Context, key types.MessageKey) (bool, error) {
+	return p.received[key.Sn], nil
+}
+
+func (p *snProvider) Rescan(ctx context.Context, from, to uint64) ([]types.BlockInfo, error) {
+	var blocks []types.BlockInfo
+	for _, m := range p.messages {
+		if m.MessageHeight < from || m.MessageHeight > to {
+			continue
+		}
+		if n := len(blocks); n > 0 && blocks[n-1].Height == m.MessageHeight {
+			blocks[n-1].Messages = append(blocks[n-1].Messages, m)
+			continue
+		}
+		blocks = append(blocks, types.BlockInfo{Height: m.MessageHeight, Messages: []*types.Message{m}})
+	}
+	return blocks, nil
+}
+
+func newSnProvider(t *testing.T, log *zap.Logger, nId, dst string, latest uint64) *snProvider {
+	mockProvider, err := GetMockChainProvider(log, time.Second, nId, dst, 10, 10)
+	require.NoError(t, err)
+	return &snProvider{ChainProvider: mockProvider, nId: nId, latest: latest, received: make(map[uint64]bool), txs: make(map[string]uint64)}
+}
+
+func (p *snProvider) emit(dst string, height uint64) {
+	p.messages = append(p.messages, &types.Message{
+		Src:           p.nId,
+		Dst:           dst,
+		Sn:            uint64(len(p.messages) + 1),
+		MessageHeight: height,
+		EventType:     events.EmitMessage,
+	})
+}
+
+func TestMessageHeight(t *testing.T) {
+	p := &snProvider{nId: "mock-1", latest: 1000}
+	for _, height := range []uint64{1, 2, 3, 400, 400, 401, 999} {
+		p.emit("mock-2", height)
+	}
+	ctx := context.Background()
+	for _, m := range p.messages {
+		height, err := messageHeight(ctx, p, m.Sn, 1000)
+		require.NoError(t, err)
+		assert.Equal(t, m.MessageHeight, height, "sn %d", m.Sn)
+	}
+}
+
+func TestAudit(t *testing.T) {
+	log := zap.NewNop()
+	src := newSnProvider(t, log, "mock-1", "mock-2", 50)
+	dst := newSnProvider(t, log, "mock-2", "mock-1", 50)
+	src.emit("mock-2", 10) // 1 received
+	src.emit("mock-2", 12) // 2 missing
+	src.emit("other", 12)  // 3 to a chain which is not configured
+	src.emit("mock-2", 20) // 4 being relayed
+	src.emit("mock-2", 30) // 5 missing
+	src.emit("mock-2", 40) // 6 not processed by the listener yet
+	dst.received[1] = true
+
+	rly, err := NewRelayer(log, memdb.NewMemDB(), map[string]*Chain{
+		"mock-1": NewChain(log, src, true),
+		"mock-2": NewChain(log, dst, true),
+	}, true)
+	require.NoError(t, err)
+	runtime, err := rly.FindChainRuntime("mock-1")
+	require.NoError(t, err)
+	runtime.LastBlockHeight = 35
+	require.NoError(t, rly.messageStore.StoreMessage(types.NewRouteMessage(src.messages[3])))
+	ctx := context.Background()
+
+	// the report only run moves the checkpoint without queueing
+	reports, err := rly.Audit(ctx, AuditOptions{Src: []string{"mock-1"}})
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	report := reports[0]
+	assert.Empty(t, report.Error)
+	assert.Equal(t, uint64(1), report.From)
+	assert.Equal(t, uint64(5), report.To)
+	assert.Equal(t, map[string]uint64{"mock-2": 1}, report.Received)
+	assert.Equal(t, uint64(1), report.Pending)
+	assert.Equal(t, uint64(1), report.Unroutable)
+	unroutable := types.MessageKey{Src: "mock-1", Dst: "other", Sn: 3, EventType: events.EmitMessage}
+	_, err = rly.unroutableStore.GetMessage(unroutable)
+	assert.Error(t, err)
+	assert.Equal(t, []Gap{
+		{Src: "mock-1", Dst: "mock-2", Sn: 2, Height: 12},
+		{Src: "mock-1", Dst: "mock-2", Sn: 5, Height: 30},
+	}, report.Gaps)
+	count, err := rly.messageStore.TotalCountByChain("mock-1")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), count)
+	audited, err := rly.auditStore.GetLastStoredBlock("mock-1")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), audited)
+
+	// the next run starts after the checkpoint
+	reports, err = rly.Audit(ctx, AuditOptions{Src: []string{"mock-1"}})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(6), reports[0].From)
+	assert.Empty(t, reports[0].Gaps)
+
+	// an explicit range is walked again and the gaps are queued
+	reports, err = rly.Audit(ctx, AuditOptions{Src: []string{"mock-1"}, From: 1, Requeue: true})
+	require.NoError(t, err)
+	report = reports[0]
+	require.Len(t, report.Gaps, 2)
+	for _, gap := range report.Gaps {
+		assert.True(t, gap.Requeued)
+		key := types.MessageKey{Src: "mock-1", Dst: "mock-2", Sn: gap.Sn, EventType: events.EmitMessage}
+		_, err := rly.messageStore.GetMessage(key)
+		assert.NoError(t, err, "sn %d", gap.Sn)
+		assert.Contains(t, runtime.MessageCache.Messages, key)
+	}
+	// the message to a chain which is not configured is parked
+	assert.Equal(t, uint64(1), report.Unroutable)
+	_, err = rly.unroutableStore.GetMessage(unroutable)
+	assert.NoError(t, err)
+	audited, err = rly.auditStore.GetLastStoredBlock("mock-1")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), audited)
+
+	// queued gaps are pending afterwards
+	reports, err = rly.Audit(ctx, AuditOptions{Src: []string{"mock-1"}, From: 1})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), reports[0].Pending)
+	assert.Empty(t, reports[0].Gaps)
+
+	_, err = rly.Audit(ctx, AuditOptions{Src: []string{"mock-3"}})
+	assert.Error(t, err)
+}
+
+func TestAuditGivesUp(t *testing.T) {
+	log := zap.NewNop()
+	src := newSnProvider(t, log, "mock-1", "mock-2", 50)
+	dst := newSnProvider(t, log, "mock-2", "mock-1", 50)
+	src.emit("mock-2", 10) // 1 cannot be generated
+	src.emit("mock-2", 20) // 2 received
+	dst.received[2] = true
+	src.generateErr = fmt.Errorf("missing trie node")
+
+	rly, err := NewRelayer(log, memdb.NewMemDB(), map[string]*Chain{
+		"mock-1": NewChain(log, src, true),
+		"mock-2": NewChain(log, dst, true),
+	}, true)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	for i := 1; i <= AuditAttempts; i++ {
+		reports, err := rly.Audit(ctx, AuditOptions{Src: []string{"mock-1"}})
+		require.NoError(t, err)
+		report := reports[0]
+		assert.Equal(t, uint64(1), report.From)
+		require.Len(t, report.Gaps, 1)
+		audited, err := rly.auditStore.GetLastStoredBlock("mock-1")
+		if i < AuditAttempts {
+			// the checkpoint waits for the sn to be resolved
+			assert.NotContains(t, report.Gaps[0].Error, "given up")
+			assert.Error(t, err)
+			continue
+		}
+		assert.Contains(t, report.Gaps[0].Error, "given up")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), audited)
+	}
+
+	// the next audit goes on after the given up sn
+	reports, err := rly.Audit(ctx, AuditOptions{Src: []string{"mock-1"}})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), reports[0].From)
+}
@@ -0,0 +1,283 @@
+package relayer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/metrics"
+	"github.com/icon-project/centralized-relay/relayer/provider"
+	"github.com/icon-project/centralized-relay/relayer/wallet"
+	"go.uber.org/zap"
+)
+
+const DefaultBalanceCheckInterval = 5 * time.Minute
+
+// BalanceLevel is the state of a relayer wallet balance against its thresholds
+type BalanceLevel string
+
+const (
+	BalanceOK       BalanceLevel = "ok"
+	BalanceWarn     BalanceLevel = "warn"
+	BalanceCritical BalanceLevel = "critical"
+)
+
+// BalanceThreshold are the balances of a chain in the display unit of the coin
+type BalanceThreshold struct {
+	Warn     float64 `yaml:"warn" json:"warn"`
+	Critical float64 `yaml:"critical" json:"critical"`
+	// PauseRouting stops relaying messages to the chain while the balance is critical
+	PauseRouting bool `yaml:"pause-routing" json:"pause-routing"`
+	// Denom is the coin of the thresholds, a balance in another coin is not compared with them
+	Denom string `yaml:"denom,omitempty" json:"denom,omitempty"`
+}
+
+func (t BalanceThreshold) Level(balance float64) BalanceLevel {
+	switch {
+	case balance < t.Critical:
+		return BalanceCritical
+	case balance < t.Warn:
+		return BalanceWarn
+	}
+	return BalanceOK
+}
+
+func (t BalanceThreshold) Validate() error {
+	if t.Warn < 0 || t.Critical < 0 {
+		return fmt.Errorf("balance thresholds must not be negative")
+	}
+	if t.Critical > t.Warn {
+		return fmt.Errorf("critical balance threshold %v is above warn threshold %v", t.Critical, t.Warn)
+	}
+	return nil
+}
+
+type BalanceMonitorConfig struct {
+	Interval time.Duration
+	// WebhookURL receives a json BalanceAlert whenever the level of a chain changes
+	WebhookURL string
+	// Thresholds by nid or chain name, chains without thresholds are not monitored
+	Thresholds map[string]BalanceThreshold
+}
+
+// BalanceAlert is posted to the webhook
+type BalanceAlert struct {
+	NID       string           `json:"nid"`
+	Chain     string           `json:"chain"`
+	Address   string           `json:"address"`
+	Balance   string           `json:"balance"`
+	Level     BalanceLevel     `json:"level"`
+	Previous  BalanceLevel     `json:"previous"`
+	Threshold BalanceThreshold `json:"threshold"`
+	Time      time.Time        `json:"time"`
+}
+
+type balanceMonitor struct {
+	log     *zap.Logger
+	cfg     BalanceMonitorConfig
+	relayer *Relayer
+	levels  map[string]BalanceLevel
+	client  *http.Client
+}
+
+// WithBalanceMonitor starts the balance monitor along with the relayer
+func WithBalanceMonitor(cfg BalanceMonitorConfig) Option {
+	return func(ctx context.Context, r *Relayer) {
+		go r.StartBalanceMonitor(ctx, cfg)
+	}
+}
+
+// StartBalanceMonitor checks the relayer wallet balances of the chains with thresholds until ctx is done
+func (r *Relayer) StartBalanceMonitor(ctx context.Context, cfg BalanceMonitorConfig) {
+	if cfg.Interval == 0 {
+		cfg.Interval = DefaultBalanceCheckInterval
+	}
+	m := &balanceMonitor{
+		log:     r.log.With(zap.String("component", "balance-monitor")),
+		cfg:     cfg,
+		relayer: r,
+		levels:  make(map[string]BalanceLevel),
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+	for {
+		m.checkAll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *balanceMonitor) checkAll(ctx context.Context) {
+	for _, chainRuntime := range m.relayer.chainRuntimes() {
+		nId := chainRuntime.Provider.NID()
+		threshold, ok := m.cfg.Thresholds[nId]
+		if !ok {
+			if threshold, ok = m.cfg.Thresholds[chainRuntime.Provider.ChainName()]; !ok {
+				continue
+			}
+		}
+		if err := m.check(ctx, chainRuntime, threshold); err != nil {
+			m.log.Error("failed to check relayer balance", zap.String("nid", nId), zap.Error(err))
+		}
+	}
+}
+
+// check checks the balance of every wallet of the chain, routing pauses once all of them are critical
+func (m *balanceMonitor) check(ctx context.Context, chainRuntime *ChainRuntime, threshold BalanceThreshold) error {
+	nId := chainRuntime.Provider.NID()
+	wallets, err := chainWallets(chainRuntime.Provider)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, w := range wallets {
+		if err := m.checkWallet(ctx, chainRuntime, w, threshold, len(wallets) > 1); err != nil {
+			errs = append(errs, fmt.Errorf("wallet %s: %w", w.Address, err))
+		}
+	}
+
+	paused := true
+	for _, w := range wallets {
+		paused = paused && w.Drained()
+	}
+	if chainRuntime.lowBalance.Swap(paused) != paused {
+		if paused {
+			m.log.Warn("pausing routing to chain until the relayer is funded", zap.String("nid", nId))
+		} else {
+			m.log.Info("resuming routing to chain", zap.String("nid", nId))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// checkWallet checks the balance of a wallet, pooled is set when the chain submits from several wallets
+func (m *balanceMonitor) checkWallet(ctx context.Context, chainRuntime *ChainRuntime, w *wallet.Wallet, threshold BalanceThreshold, pooled bool) error {
+	nId, addr := chainRuntime.Provider.NID(), w.Address
+	coin, err := chainRuntime.Provider.QueryBalance(ctx, addr)
+	if err != nil {
+		return err
+	}
+	if coin == nil {
+		return nil
+	}
+	if threshold.Denom != "" && coin.Denom != threshold.Denom {
+		return fmt.Errorf("balance is in %s, the thresholds are in %s", coin.Denom, threshold.Denom)
+	}
+
+	balance := coin.Float64()
+	level := threshold.Level(balance)
+	metrics.WalletBalance.WithLabelValues(nId, addr, coin.Denom).Set(balance)
+	metrics.WalletBalanceLevel.WithLabelValues(nId, addr).Set(levelValue(level))
+
+	fields := []zap.Field{
+		zap.String("nid", nId),
+		zap.String("address", addr),
+		zap.String("balance", coin.String()),
+		zap.String("level", string(level)),
+	}
+	switch level {
+	case BalanceCritical:
+		m.log.Error("relayer balance is critically low", fields...)
+	case BalanceWarn:
+		m.log.Warn("relayer balance is low", fields...)
+	default:
+		m.log.Debug("relayer balance", fields...)
+	}
+
+	// a drained wallet gets no transaction, the others of the pool keep submitting
+	if drained := level == BalanceCritical && threshold.PauseRouting; w.SetDrained(drained) && pooled {
+		if drained {
+			m.log.Warn("wallet drained, skipping it until it is funded", fields...)
+		} else {
+			m.log.Info("wallet funded, submitting from it again", fields...)
+		}
+	}
+
+	key := nId + "/" + addr
+	previous, seen := m.levels[key]
+	m.levels[key] = level
+	if previous == level || (!seen && level == BalanceOK) {
+		return nil
+	}
+	if !seen {
+		previous = BalanceOK
+	}
+	return m.notify(ctx, BalanceAlert{
+		NID:       nId,
+		Chain:     chainRuntime.Provider.ChainName(),
+		Address:   addr,
+		Balance:   coin.String(),
+		Level:     level,
+		Previous:  previous,
+		Threshold: threshold,
+		Time:      time.Now().UTC(),
+	})
+}
+
+// notify posts the alert to the webhook if one is configured
+func (m *balanceMonitor) notify(ctx context.Context, alert BalanceAlert) error {
+	if m.cfg.WebhookURL == "" {
+		return nil
+	}
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.cfg.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("balance webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("balance webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+func levelValue(level BalanceLevel) float64 {
+	switch level {
+	case BalanceCritical:
+		return 2
+	case BalanceWarn:
+		return 1
+	}
+	return 0
+}
+
+// walletPool is implemented by the providers submitting from several wallets
+type walletPool interface {
+	Wallets() *wallet.Pool
+}
+
+// chainWallets returns the wallets of the pool of the provider,
+// the wallet of GetWalletAddress for the providers without pool
+func chainWallets(p provider.ChainProvider) ([]*wallet.Wallet, error) {
+	if pool, ok := p.(walletPool); ok && pool.Wallets() != nil {
+		return pool.Wallets().Wallets(), nil
+	}
+	addr, err := p.GetWalletAddress()
+	if err != nil {
+		return nil, err
+	}
+	return []*wallet.Wallet{wallet.New(addr, nil)}, nil
+}
+
+// routingPaused reports whether messages must not be routed to the chain
+func (r *ChainRuntime) routingPaused() bool {
+	return r.lowBalance.Load()
+}
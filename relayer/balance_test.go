@@ -0,0 +1,151 @@
+package relayer
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/chains/mockchain"
+	"github.com/icon-project/centralized-relay/relayer/memdb"
+	"github.com/icon-project/centralized-relay/relayer/provider"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/icon-project/centralized-relay/relayer/wallet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestBalanceThresholdLevel(t *testing.T) {
+	threshold := BalanceThreshold{Warn: 10, Critical: 2}
+	assert.Equal(t, BalanceOK, threshold.Level(10))
+	assert.Equal(t, BalanceWarn, threshold.Level(9.9))
+	assert.Equal(t, BalanceCritical, threshold.Level(1))
+	assert.NoError(t, threshold.Validate())
+	assert.Error(t, BalanceThreshold{Warn: 1, Critical: 2}.Validate())
+}
+
+func TestBalanceMonitor(t *testing.T) {
+	t.Parallel()
+	alerts := make(chan BalanceAlert, 10)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var alert BalanceAlert
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&alert))
+		alerts <- alert
+	}))
+	defer webhook.Close()
+
+	log := zap.NewNop()
+	mockProvider, err := GetMockChainProvider(log, time.Second, "mock-1", "mock-2", 10, 10)
+	require.NoError(t, err)
+	pCfg := mockProvider.(*mockchain.MockProvider).PCfg
+	pCfg.Balance = big.NewInt(100)
+	rly, err := NewRelayer(log, memdb.NewMemDB(), map[string]*Chain{"mock-1": NewChain(log, mockProvider, true)}, true)
+	require.NoError(t, err)
+	runtime, err := rly.FindChainRuntime("mock-1")
+	require.NoError(t, err)
+
+	m := &balanceMonitor{
+		log:     log,
+		cfg:     BalanceMonitorConfig{WebhookURL: webhook.URL},
+		relayer: rly,
+		levels:  make(map[string]BalanceLevel),
+		client:  webhook.Client(),
+	}
+	threshold := BalanceThreshold{Warn: 50, Critical: 10, PauseRouting: true}
+	ctx := context.Background()
+
+	// healthy balance does not alert
+	require.NoError(t, m.check(ctx, runtime, threshold))
+	assert.Len(t, alerts, 0)
+	assert.False(t, runtime.routingPaused())
+
+	pCfg.Balance = big.NewInt(5)
+	require.NoError(t, m.check(ctx, runtime, threshold))
+	alert := <-alerts
+	assert.Equal(t, BalanceCritical, alert.Level)
+	assert.Equal(t, BalanceOK, alert.Previous)
+	assert.Equal(t, "mock-1", alert.NID)
+	assert.True(t, runtime.routingPaused())
+
+	// same level does not alert again
+	require.NoError(t, m.check(ctx, runtime, threshold))
+	assert.Len(t, alerts, 0)
+
+	pCfg.Balance = big.NewInt(60)
+	require.NoError(t, m.check(ctx, runtime, threshold))
+	alert = <-alerts
+	assert.Equal(t, BalanceOK, alert.Level)
+	assert.Equal(t, BalanceCritical, alert.Previous)
+	assert.False(t, runtime.routingPaused())
+
+	// thresholds of another coin are not applied
+	threshold.Denom = "eth"
+	assert.ErrorContains(t, m.check(ctx, runtime, threshold), "balance is in mock, the thresholds are in eth")
+	threshold.Denom = "mock"
+	assert.NoError(t, m.check(ctx, runtime, threshold))
+}
+
+// pooledProvider submits from a wallet pool with a balance per wallet
+type pooledProvider struct {
+	provider.ChainProvider
+	pool     *wallet.Pool
+	balances map[string]int64
+}
+
+func (p *pooledProvider) Wallets() *wallet.Pool {
+	return p.pool
+}
+
+func (p *pooledProvider) QueryBalance(ctx context.Context, addr string) (*types.Coin, error) {
+	coin := types.NewCoin("mock", big.NewInt(p.balances[addr]), 0)
+	return &coin, nil
+}
+
+func newPooledProvider(t *testing.T, log *zap.Logger, balances map[string]int64, addresses ...string) *pooledProvider {
+	mockProvider, err := GetMockChainProvider(log, time.Second, "mock-1", "mock-2", 10, 10)
+	require.NoError(t, err)
+	var wallets []*wallet.Wallet
+	for _, addr := range addresses {
+		wallets = append(wallets, wallet.New(addr, nil))
+	}
+	pool, err := wallet.NewPool(wallet.RoundRobin, wallets...)
+	require.NoError(t, err)
+	return &pooledProvider{ChainProvider: mockProvider, pool: pool, balances: balances}
+}
+
+func TestBalanceMonitorWallets(t *testing.T) {
+	t.Parallel()
+	log := zap.NewNop()
+	balances := map[string]int64{"wallet-1": 100, "wallet-2": 5}
+	p := newPooledProvider(t, log, balances, "wallet-1", "wallet-2")
+	rly, err := NewRelayer(log, memdb.NewMemDB(), map[string]*Chain{"mock-1": NewChain(log, p, true)}, true)
+	require.NoError(t, err)
+	runtime, err := rly.FindChainRuntime("mock-1")
+	require.NoError(t, err)
+
+	m := &balanceMonitor{log: log, relayer: rly, levels: make(map[string]BalanceLevel)}
+	threshold := BalanceThreshold{Warn: 50, Critical: 10, PauseRouting: true}
+	ctx := context.Background()
+
+	// the drained wallet is skipped, the chain keeps routing from the other one
+	require.NoError(t, m.check(ctx, runtime, threshold))
+	wallets := p.pool.Wallets()
+	assert.False(t, wallets[0].Drained())
+	assert.True(t, wallets[1].Drained())
+	assert.False(t, runtime.routingPaused())
+	assert.Equal(t, "wallet-1", p.pool.Acquire().Address)
+	assert.Equal(t, "wallet-1", p.pool.Acquire().Address)
+
+	balances["wallet-1"] = 1
+	require.NoError(t, m.check(ctx, runtime, threshold))
+	assert.True(t, runtime.routingPaused())
+
+	balances["wallet-2"] = 60
+	require.NoError(t, m.check(ctx, runtime, threshold))
+	assert.False(t, wallets[1].Drained())
+	assert.False(t, runtime.routingPaused())
+}
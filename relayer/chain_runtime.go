@@ -3,6 +3,8 @@ package relayer
 import (
 	"context"
 	"fmt"
+	"sync"
+	"sync/atomic"
 
 	"github.com/icon-project/centralized-relay/relayer/provider"
 	"github.com/icon-project/centralized-relay/relayer/types"
@@ -16,6 +18,11 @@ type ChainRuntime struct {
 	LastBlockHeight uint64
 	LastSavedHeight uint64
 	MessageCache    *types.MessageCache
+	// lowBalance is set while routing to the chain is paused because the relayer wallet is running dry
+	lowBalance atomic.Bool
+	// cancel stops the listener and the block processor of the chain, wg waits for them
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
 }
 
 func NewChainRuntime(log *zap.Logger, chain *Chain) (*ChainRuntime, error) {
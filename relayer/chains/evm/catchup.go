@@ -0,0 +1,166 @@
+package evm
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/icon-project/centralized-relay/relayer/chains/evm/types"
+	relayertypes "github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// DefaultCatchUpWindow is the number of blocks queried by a single eth_getLogs request while catching up
+const DefaultCatchUpWindow = 1000
+
+// catchUp relays the blocks from..to with range eth_getLogs requests and returns the next height to process.
+// the window shrinks when the rpc rejects a range and grows back after successful requests
+func (r *EVMProvider) catchUp(ctx context.Context, from, to uint64, blockInfoChan chan relayertypes.BlockInfo) (uint64, error) {
+	return r.filterLogs(ctx, from, to, &r.logWindow, func(from, end uint64, byHeight map[uint64][]ethTypes.Log) (uint64, error) {
+		for h := from; h <= end; h++ {
+			if len(byHeight[h]) == 0 {
+				if h != end {
+					continue
+				}
+			} else if err := r.checkBlockHash(ctx, h, byHeight[h]); err != nil {
+				return h, err
+			}
+			if err := r.relayBlock(ctx, h, byHeight[h], blockInfoChan); err != nil {
+				return h, err
+			}
+		}
+		return end + 1, nil
+	})
+}
+
+// Rescan parses the messages of the blocks from..to which emitted events, the listener and its checkpoint are not affected
+func (r *EVMProvider) Rescan(ctx context.Context, from, to uint64) ([]relayertypes.BlockInfo, error) {
+	var window uint64
+	blocks := make([]relayertypes.BlockInfo, 0)
+	_, err := r.filterLogs(ctx, from, to, &window, func(from, end uint64, byHeight map[uint64][]ethTypes.Log) (uint64, error) {
+		for h := from; h <= end; h++ {
+			if len(byHeight[h]) == 0 {
+				continue
+			}
+			if err := r.checkBlockHash(ctx, h, byHeight[h]); err != nil {
+				return h, err
+			}
+			messages, quarantined, err := r.FindMessages(ctx, &types.BlockNotification{
+				Height: new(big.Int).SetUint64(h),
+				Logs:   byHeight[h],
+			})
+			if err != nil {
+				return h, err
+			}
+			blocks = append(blocks, relayertypes.BlockInfo{Height: h, Messages: messages, Quarantined: quarantined})
+		}
+		return end + 1, nil
+	})
+	return blocks, err
+}
+
+// filterLogs queries the logs of from..to with range eth_getLogs requests of at most window blocks and hands
+// the logs of each range by height to process, which returns the next height. The window shrinks when the
+// rpc rejects a range and grows back up to the catch-up-window after successful requests
+func (r *EVMProvider) filterLogs(ctx context.Context, from, to uint64, window *uint64, process func(from, end uint64, byHeight map[uint64][]ethTypes.Log) (uint64, error)) (uint64, error) {
+	maxWindow := r.cfg.CatchUpWindow
+	if maxWindow == 0 {
+		maxWindow = DefaultCatchUpWindow
+	}
+	if *window == 0 || *window > maxWindow {
+		*window = maxWindow
+	}
+
+	for from <= to {
+		end := from + *window - 1
+		if end > to {
+			end = to
+		}
+		query := r.blockReq
+		query.FromBlock, query.ToBlock = new(big.Int).SetUint64(from), new(big.Int).SetUint64(end)
+		logs, err := r.client.FilterLogs(ctx, query)
+		if err != nil {
+			if isRangeTooLarge(err) && *window > 1 {
+				*window /= 2
+				r.log.Debug("eth_getLogs range rejected, shrinking window", zap.Uint64("window", *window), zap.Error(err))
+				continue
+			}
+			return from, errors.Wrapf(err, "FilterLogs %d-%d", from, end)
+		}
+
+		byHeight := make(map[uint64][]ethTypes.Log)
+		for _, log := range logs {
+			byHeight[log.BlockNumber] = append(byHeight[log.BlockNumber], log)
+		}
+		next, err := process(from, end, byHeight)
+		if err != nil {
+			return next, err
+		}
+		r.log.Debug("queried logs", zap.Uint64("from", from), zap.Uint64("to", end), zap.Int("logs", len(logs)))
+		from = next
+		if *window < maxWindow {
+			*window = min(*window*2, maxWindow)
+		}
+	}
+	return from, nil
+}
+
+// checkBlockHash fetches the header of a block containing events
+// and makes sure the logs belong to the canonical block
+func (r *EVMProvider) checkBlockHash(ctx context.Context, height uint64, logs []ethTypes.Log) error {
+	header, err := r.client.GetHeaderByHeight(ctx, new(big.Int).SetUint64(height))
+	if err != nil {
+		return errors.Wrapf(err, "GetHeaderByHeight %d", height)
+	}
+	for _, log := range logs {
+		if log.BlockHash != header.Hash() {
+			return fmt.Errorf("block hash mismatch at height %d: log %s, header %s, possible reorg", height, log.BlockHash, header.Hash())
+		}
+	}
+	return nil
+}
+
+// isRangeTooLarge reports whether the rpc rejected an eth_getLogs request because of the range or result size
+func isRangeTooLarge(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, s := range []string{
+		"too many results",
+		"query returned more than",
+		"limit exceeded",
+		"response size",
+		"block range",
+		"range is too large",
+		"range too large",
+		"exceed maximum",
+		"query timeout",
+	} {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// relayBlock parses the logs of a block and forwards them to the relayer
+func (r *EVMProvider) relayBlock(ctx context.Context, height uint64, logs []ethTypes.Log, blockInfoChan chan relayertypes.BlockInfo) error {
+	messages, quarantined, err := r.findMessagesUntilVerified(ctx, &types.BlockNotification{
+		Height: new(big.Int).SetUint64(height),
+		Logs:   logs,
+	})
+	if err != nil {
+		return err
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case blockInfoChan <- relayertypes.BlockInfo{
+		Height:      height,
+		Messages:    messages,
+		Quarantined: quarantined,
+	}:
+	}
+	return nil
+}
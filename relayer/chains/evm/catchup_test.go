@@ -0,0 +1,149 @@
+package evm
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	bridgeContract "github.com/icon-project/centralized-relay/relayer/chains/evm/abi"
+	relayertypes "github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// rangeClient records the ranges of the eth_getLogs requests and rejects ranges above maxRange
+type rangeClient struct {
+	IClient
+	maxRange uint64
+	ranges   [][2]uint64
+	headers  int
+}
+
+func (c *rangeClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]ethTypes.Log, error) {
+	from, to := q.FromBlock.Uint64(), q.ToBlock.Uint64()
+	if c.maxRange > 0 && to-from+1 > c.maxRange {
+		return nil, errors.New("query returned more than 10000 results")
+	}
+	c.ranges = append(c.ranges, [2]uint64{from, to})
+	return nil, nil
+}
+
+func (c *rangeClient) GetHeaderByHeight(ctx context.Context, height *big.Int) (*ethTypes.Header, error) {
+	c.headers++
+	return &ethTypes.Header{Number: height, Difficulty: big.NewInt(0)}, nil
+}
+
+func newCatchUpProvider(client IClient, window uint64) *EVMProvider {
+	return &EVMProvider{
+		client:   client,
+		verifier: client,
+		log:      zap.NewNop(),
+		cfg:      &EVMProviderConfig{CatchUpWindow: window},
+		blockReq: getEventFilterQuery("0x0165878A594ca255338adfa4d48449f69242Eb8F"),
+	}
+}
+
+func TestCatchUp(t *testing.T) {
+	client := &rangeClient{}
+	p := newCatchUpProvider(client, 100)
+	blockInfoChan := make(chan relayertypes.BlockInfo, 10)
+
+	next, err := p.catchUp(context.Background(), 10, 130, blockInfoChan)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(131), next)
+	assert.Equal(t, [][2]uint64{{10, 109}, {110, 130}}, client.ranges)
+	assert.Zero(t, client.headers)
+
+	// blocks without logs are skipped except the last block of each window
+	require.Len(t, blockInfoChan, 2)
+	assert.Equal(t, uint64(109), (<-blockInfoChan).Height)
+	assert.Equal(t, uint64(130), (<-blockInfoChan).Height)
+}
+
+func TestCatchUpAdaptiveWindow(t *testing.T) {
+	client := &rangeClient{maxRange: 30}
+	p := newCatchUpProvider(client, 100)
+	blockInfoChan := make(chan relayertypes.BlockInfo, 100)
+
+	next, err := p.catchUp(context.Background(), 1, 100, blockInfoChan)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(101), next)
+	// 100 and 50 are rejected, 25 succeeds and doubling back to 50 is rejected again
+	assert.Equal(t, [2]uint64{1, 25}, client.ranges[0])
+	assert.Equal(t, [2]uint64{26, 50}, client.ranges[1])
+	for _, r := range client.ranges {
+		assert.LessOrEqual(t, r[1]-r[0]+1, uint64(30))
+	}
+	assert.Equal(t, uint64(100), client.ranges[len(client.ranges)-1][1])
+}
+
+// logClient serves an emit message log with sn i+1 at heights[i]
+type logClient struct {
+	rangeClient
+	heights []uint64
+}
+
+func (c *logClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]ethTypes.Log, error) {
+	if _, err := c.rangeClient.FilterLogs(ctx, q); err != nil {
+		return nil, err
+	}
+	header, _ := c.GetHeaderByHeight(ctx, big.NewInt(0))
+	var logs []ethTypes.Log
+	for i, h := range c.heights {
+		if h >= q.FromBlock.Uint64() && h <= q.ToBlock.Uint64() {
+			header.Number = new(big.Int).SetUint64(h)
+			logs = append(logs, ethTypes.Log{
+				Topics:      []common.Hash{crypto.Keccak256Hash([]byte(EmitMessageSig))},
+				BlockNumber: h,
+				BlockHash:   header.Hash(),
+				Index:       uint(i + 1),
+			})
+		}
+	}
+	return logs, nil
+}
+
+func (c *logClient) ParseMessage(log ethTypes.Log) (*bridgeContract.AbiMessage, error) {
+	return &bridgeContract.AbiMessage{TargetNetwork: "0x2.icon", Sn: big.NewInt(int64(log.Index))}, nil
+}
+
+func TestRescan(t *testing.T) {
+	client := &logClient{rangeClient: rangeClient{maxRange: 30}, heights: []uint64{5, 40, 40, 90}}
+	p := newCatchUpProvider(client, 100)
+	p.cfg.NID = "0x13881.mumbai"
+	p.logWindow = 7
+
+	blocks, err := p.Rescan(context.Background(), 10, 100)
+	require.NoError(t, err)
+	require.Len(t, blocks, 2)
+	assert.Equal(t, uint64(40), blocks[0].Height)
+	require.Len(t, blocks[0].Messages, 2)
+	assert.Equal(t, uint64(2), blocks[0].Messages[0].Sn)
+	assert.Equal(t, "0x2.icon", blocks[0].Messages[0].Dst)
+	assert.Equal(t, uint64(90), blocks[1].Height)
+	assert.Equal(t, uint64(4), blocks[1].Messages[0].Sn)
+	assert.Equal(t, uint64(100), client.ranges[len(client.ranges)-1][1])
+	// the window of the listener is left as is
+	assert.Equal(t, uint64(7), p.logWindow)
+}
+
+func TestCheckBlockHash(t *testing.T) {
+	client := &rangeClient{}
+	p := newCatchUpProvider(client, 100)
+	header, _ := client.GetHeaderByHeight(context.Background(), big.NewInt(5))
+
+	assert.NoError(t, p.checkBlockHash(context.Background(), 5, []ethTypes.Log{{BlockHash: header.Hash()}}))
+	assert.Error(t, p.checkBlockHash(context.Background(), 5, []ethTypes.Log{{BlockHash: common.HexToHash("0x01")}}))
+}
+
+func TestIsRangeTooLarge(t *testing.T) {
+	assert.True(t, isRangeTooLarge(errors.New("Log response size exceeded. You can make eth_getLogs requests with up to a 2K block range")))
+	assert.True(t, isRangeTooLarge(errors.New("query returned more than 10000 results")))
+	assert.False(t, isRangeTooLarge(errors.New("connection refused")))
+}
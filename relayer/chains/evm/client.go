@@ -75,6 +75,7 @@ type IClient interface {
 	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]ethTypes.Log, error)
 	SuggestGasPrice(ctx context.Context) (*big.Int, error)
 	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
 	TransactionByHash(ctx context.Context, blockHash common.Hash) (tx *ethTypes.Transaction, isPending bool, err error)
 	CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
 	TransactionReceipt(ctx context.Context, txHash common.Hash) (*ethTypes.Receipt, error)
@@ -89,12 +90,17 @@ type IClient interface {
 	SendMessage(opts *bind.TransactOpts, _to string, _svc string, _sn *big.Int, _msg []byte) (*ethTypes.Transaction, error)
 	ReceiveMessage(opts *bind.TransactOpts, srcNID string, sn *big.Int, msg []byte) (*ethTypes.Transaction, error)
 	MessageReceived(opts *bind.CallOpts, srcNetwork string, _connSn *big.Int) (bool, error)
+	ConnSn(opts *bind.CallOpts) (*big.Int, error)
 }
 
 func (cl *Client) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
 	return cl.eth.NonceAt(ctx, account, blockNumber)
 }
 
+func (cl *Client) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return cl.eth.PendingNonceAt(ctx, account)
+}
+
 func (cl *Client) ParseMessage(log ethTypes.Log) (*bridgeContract.AbiMessage, error) {
 	return cl.bridgeContract.ParseMessage(log)
 }
@@ -280,3 +286,7 @@ func (c *Client) SendTransaction(ctx context.Context, tx *ethTypes.Transaction)
 func (c *Client) MessageReceived(opts *bind.CallOpts, srcNetwork string, _connSn *big.Int) (bool, error) {
 	return c.bridgeContract.GetReceipt(opts, srcNetwork, _connSn)
 }
+
+func (c *Client) ConnSn(opts *bind.CallOpts) (*big.Int, error) {
+	return c.bridgeContract.ConnSn(opts)
+}
@@ -1,10 +1,13 @@
 package evm
 
 import (
+	"crypto/ecdsa"
 	"io"
 	"os"
 
 	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
 )
 
 func RestoreKey(keystoreFile string, secret string) (*keystore.Key, error) {
@@ -24,3 +27,42 @@ func RestoreKey(keystoreFile string, secret string) (*keystore.Key, error) {
 	}
 	return key, nil
 }
+
+// NewKeystore encrypts the private key as a web3 keystore, a new key is generated when privateKey is nil
+func NewKeystore(privateKey []byte, secret string) ([]byte, string, error) {
+	var (
+		pk  *ecdsa.PrivateKey
+		err error
+	)
+	if privateKey == nil {
+		pk, err = crypto.GenerateKey()
+	} else {
+		pk, err = crypto.ToECDSA(privateKey)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, "", err
+	}
+	key := &keystore.Key{
+		Id:         id,
+		Address:    crypto.PubkeyToAddress(pk.PublicKey),
+		PrivateKey: pk,
+	}
+	data, err := keystore.EncryptKey(key, secret, keystore.StandardScryptN, keystore.StandardScryptP)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, key.Address.String(), nil
+}
+
+// DecryptKeystore returns the address and the private key of the keystore
+func DecryptKeystore(data []byte, secret string) (string, []byte, error) {
+	key, err := keystore.DecryptKey(data, secret)
+	if err != nil {
+		return "", nil, err
+	}
+	return key.Address.String(), crypto.FromECDSA(key.PrivateKey), nil
+}
@@ -3,6 +3,8 @@ package evm
 import (
 	"testing"
 
+	"github.com/ethereum/go-ethereum/crypto"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -17,3 +19,20 @@ func TestRestoreKey(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, key.Address.String(), expectedAddr)
 }
+
+func TestNewKeystore(t *testing.T) {
+	key, err := RestoreKey(testKeyStore, testKeyPassword)
+	assert.NoError(t, err)
+
+	data, addr, err := NewKeystore(crypto.FromECDSA(key.PrivateKey), "new-secret")
+	assert.NoError(t, err)
+	assert.Equal(t, expectedAddr, addr)
+
+	addr, privateKey, err := DecryptKeystore(data, "new-secret")
+	assert.NoError(t, err)
+	assert.Equal(t, expectedAddr, addr)
+	assert.Equal(t, crypto.FromECDSA(key.PrivateKey), privateKey)
+
+	_, _, err = DecryptKeystore(data, testKeyPassword)
+	assert.Error(t, err)
+}
@@ -20,6 +20,10 @@ const (
 	defaultReadTimeout         = 15 * time.Second
 	monitorBlockMaxConcurrency = 10 // number of concurrent requests to synchronize older blocks from source chain
 	DefaultFinalityBlock       = 10
+	// DefaultDenom is the native coin of the chains without denom in their config
+	DefaultDenom = "eth"
+	// catchUpThreshold is the number of blocks behind the head after which the catch-up is used
+	catchUpThreshold = 20
 )
 
 type BnOptions struct {
@@ -44,6 +48,14 @@ func (r *EVMProvider) Listener(ctx context.Context, lastSavedHeight uint64, bloc
 
 	r.log.Info("Start query from height ", zap.Uint64("start-height", startHeight), zap.Uint64("finality block", r.FinalityBlock(ctx)))
 
+	if mc, ok := r.client.(*MultiClient); ok {
+		go mc.StartHealthCheck(ctx)
+	}
+
+	if r.cfg.WebsocketUrl != "" {
+		return r.subscriptionListener(ctx, startHeight, blockInfoChan)
+	}
+
 	heightTicker := time.NewTicker(BlockInterval)
 	defer heightTicker.Stop()
 
@@ -85,13 +97,14 @@ func (r *EVMProvider) Listener(ctx context.Context, lastSavedHeight uint64, bloc
 					r.log.Debug("block-notification received", zap.Uint64("height", lbn.Height.Uint64()),
 						zap.Int64("gas-used", int64(lbn.Header.GasUsed)))
 
-					messages, err := r.FindMessages(ctx, lbn)
+					messages, quarantined, err := r.findMessagesUntilVerified(ctx, lbn)
 					if err != nil {
-						return errors.Wrapf(err, "receiveLoop: callback: %v", err)
+						return errors.Wrap(err, "receiveLoop: callback")
 					}
 					blockInfoChan <- relayertypes.BlockInfo{
-						Height:   lbn.Height.Uint64(),
-						Messages: messages,
+						Height:      lbn.Height.Uint64(),
+						Messages:    messages,
+						Quarantined: quarantined,
 					}
 				}
 
@@ -110,6 +123,27 @@ func (r *EVMProvider) Listener(ctx context.Context, lastSavedHeight uint64, bloc
 				continue
 			}
 
+			// far behind the head, catch up with range eth_getLogs instead of fetching every header
+			if latest-next > catchUpThreshold {
+				if lbn != nil {
+					if err := r.relayBlock(ctx, lbn.Height.Uint64(), lbn.Logs, blockInfoChan); err != nil {
+						return err
+					}
+					lbn = nil
+				}
+				// latest is advanced by the ticker, so the range is bounded by the real head
+				if head := r.latestHeight(); head != 0 && head < latest {
+					latest = head
+				}
+				n, err := r.catchUp(ctx, next, latest-1, blockInfoChan)
+				if err != nil {
+					r.log.Error("evm listener: failed to catch up", zap.Uint64("next-height", n), zap.Error(err))
+					time.Sleep(BlockInterval)
+				}
+				next = n
+				continue
+			}
+
 			type bnq struct {
 				h     uint64
 				v     *types.BlockNotification
@@ -199,15 +233,36 @@ func (r *EVMProvider) Listener(ctx context.Context, lastSavedHeight uint64, bloc
 	}
 }
 
-func (p *EVMProvider) FindMessages(ctx context.Context, lbn *types.BlockNotification) ([]*relayertypes.Message, error) {
-	if lbn == nil && lbn.Logs == nil {
-		return nil, nil
+// FindMessages parses the messages of the block, when a verifier rpc is configured
+// messages which cannot be confirmed by the verifier are returned as quarantined
+func (p *EVMProvider) FindMessages(ctx context.Context, lbn *types.BlockNotification) ([]*relayertypes.Message, []*relayertypes.Message, error) {
+	if lbn == nil || lbn.Logs == nil {
+		return nil, nil, nil
+	}
+	var mismatches []error
+	if p.verifyEnabled() {
+		var err error
+		if mismatches, err = p.verifyLogs(ctx, lbn.Height.Uint64(), lbn.Logs); err != nil {
+			return nil, nil, err
+		}
 	}
 	messages := make([]*relayertypes.Message, 0)
-	for _, log := range lbn.Logs {
+	var quarantined []*relayertypes.Message
+	for i, log := range lbn.Logs {
 		message, err := p.getRelayMessageFromLog(log)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+		if mismatches != nil && mismatches[i] != nil {
+			p.log.Error("ALERT: message rejected by verifier rpc, quarantined",
+				zap.Uint64("height", lbn.Height.Uint64()),
+				zap.String("tx-hash", log.TxHash.Hex()),
+				zap.String("target-network", message.Dst),
+				zap.Uint64("sn", message.Sn),
+				zap.Error(mismatches[i]),
+			)
+			quarantined = append(quarantined, message)
+			continue
 		}
 		p.log.Debug("detected eventlog ", zap.Uint64("height", lbn.Height.Uint64()),
 			zap.String("target-network", message.Dst),
@@ -216,7 +271,7 @@ func (p *EVMProvider) FindMessages(ctx context.Context, lbn *types.BlockNotifica
 		)
 		messages = append(messages, message)
 	}
-	return messages, nil
+	return messages, quarantined, nil
 }
 
 func (p *EVMProvider) GetConcurrency(ctx context.Context, startHeight, currentHeight uint64) int {
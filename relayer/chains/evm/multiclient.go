@@ -0,0 +1,222 @@
+package evm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	bridgeContract "github.com/icon-project/centralized-relay/relayer/chains/evm/abi"
+	types "github.com/icon-project/centralized-relay/relayer/chains/evm/types"
+	"github.com/icon-project/centralized-relay/relayer/endpoint"
+	"go.uber.org/zap"
+)
+
+var _ IClient = (*MultiClient)(nil)
+
+// MultiClient routes every call to the healthiest rpc endpoint and fails over to the next one when the
+// endpoint cannot be reached. Errors answered by the endpoint, such as reverts or not found, are returned
+// as is and do not count against its health
+type MultiClient struct {
+	log  *zap.Logger
+	pool *endpoint.Pool[IClient]
+}
+
+// newMultiClient dials all the urls, endpoints which cannot be reached are skipped
+func newMultiClient(urls []string, contractAddress string, nid string, l *zap.Logger) (*MultiClient, error) {
+	var endpoints []*endpoint.Endpoint[IClient]
+	for _, url := range urls {
+		client, err := newClient(url, contractAddress, l)
+		if err != nil {
+			l.Warn("failed to connect rpc endpoint", zap.String("url", url), zap.Error(err))
+			continue
+		}
+		endpoints = append(endpoints, &endpoint.Endpoint[IClient]{URL: url, Client: client})
+	}
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("failed to connect any of the rpc endpoints %v", urls)
+	}
+	for _, ep := range endpoints[1:] {
+		if ep.Client.GetChainID().Cmp(endpoints[0].Client.GetChainID()) != 0 {
+			return nil, fmt.Errorf("rpc endpoint %s is on chain id %v, expected %v", ep.URL, ep.Client.GetChainID(), endpoints[0].Client.GetChainID())
+		}
+	}
+	pool, err := endpoint.NewPool(l, nid, endpoints...)
+	if err != nil {
+		return nil, err
+	}
+	return &MultiClient{log: l, pool: pool}, nil
+}
+
+// StartHealthCheck keeps the endpoint health up to date until ctx is done
+func (c *MultiClient) StartHealthCheck(ctx context.Context) {
+	c.pool.StartHealthCheck(ctx, endpoint.DefaultHealthCheckInterval, func(ctx context.Context, cl IClient) (uint64, error) {
+		return cl.GetBlockNumber()
+	})
+}
+
+// Health returns the health of the rpc endpoints
+func (c *MultiClient) Health() []endpoint.Health {
+	return c.pool.Health()
+}
+
+func (c *MultiClient) Log() *zap.Logger {
+	return c.log
+}
+
+func (c *MultiClient) GetChainID() *big.Int {
+	return c.pool.Best().GetChainID()
+}
+
+func (c *MultiClient) GetBalance(ctx context.Context, hexAddr string) (*big.Int, error) {
+	return endpoint.DoWith(c.pool, isTransportError, func(cl IClient) (*big.Int, error) {
+		return cl.GetBalance(ctx, hexAddr)
+	})
+}
+
+func (c *MultiClient) GetBlockNumber() (uint64, error) {
+	return endpoint.DoWith(c.pool, isTransportError, func(cl IClient) (uint64, error) {
+		return cl.GetBlockNumber()
+	})
+}
+
+func (c *MultiClient) GetBlockByHash(hash common.Hash) (*types.Block, error) {
+	return endpoint.DoWith(c.pool, isTransportError, func(cl IClient) (*types.Block, error) {
+		return cl.GetBlockByHash(hash)
+	})
+}
+
+func (c *MultiClient) GetHeaderByHeight(ctx context.Context, height *big.Int) (*ethTypes.Header, error) {
+	return endpoint.DoWith(c.pool, isTransportError, func(cl IClient) (*ethTypes.Header, error) {
+		return cl.GetHeaderByHeight(ctx, height)
+	})
+}
+
+func (c *MultiClient) GetBlockReceipts(hash common.Hash) (ethTypes.Receipts, error) {
+	return endpoint.DoWith(c.pool, isTransportError, func(cl IClient) (ethTypes.Receipts, error) {
+		return cl.GetBlockReceipts(hash)
+	})
+}
+
+func (c *MultiClient) GetMedianGasPriceForBlock(ctx context.Context) (*big.Int, *big.Int, error) {
+	type result struct{ gasPrice, gasHeight *big.Int }
+	res, err := endpoint.DoWith(c.pool, isTransportError, func(cl IClient) (result, error) {
+		gasPrice, gasHeight, err := cl.GetMedianGasPriceForBlock(ctx)
+		return result{gasPrice, gasHeight}, err
+	})
+	return res.gasPrice, res.gasHeight, err
+}
+
+func (c *MultiClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]ethTypes.Log, error) {
+	return endpoint.DoWith(c.pool, isTransportError, func(cl IClient) ([]ethTypes.Log, error) {
+		return cl.FilterLogs(ctx, q)
+	})
+}
+
+func (c *MultiClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return endpoint.DoWith(c.pool, isTransportError, func(cl IClient) (*big.Int, error) {
+		return cl.SuggestGasPrice(ctx)
+	})
+}
+
+func (c *MultiClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return endpoint.DoWith(c.pool, isTransportError, func(cl IClient) (uint64, error) {
+		return cl.NonceAt(ctx, account, blockNumber)
+	})
+}
+
+func (c *MultiClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return endpoint.DoWith(c.pool, isTransportError, func(cl IClient) (uint64, error) {
+		return cl.PendingNonceAt(ctx, account)
+	})
+}
+
+func (c *MultiClient) TransactionByHash(ctx context.Context, hash common.Hash) (*ethTypes.Transaction, bool, error) {
+	type result struct {
+		tx        *ethTypes.Transaction
+		isPending bool
+	}
+	res, err := endpoint.DoWith(c.pool, isTransportError, func(cl IClient) (result, error) {
+		tx, isPending, err := cl.TransactionByHash(ctx, hash)
+		return result{tx, isPending}, err
+	})
+	return res.tx, res.isPending, err
+}
+
+func (c *MultiClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return endpoint.DoWith(c.pool, isTransportError, func(cl IClient) ([]byte, error) {
+		return cl.CallContract(ctx, msg, blockNumber)
+	})
+}
+
+func (c *MultiClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*ethTypes.Receipt, error) {
+	return endpoint.DoWith(c.pool, isTransportError, func(cl IClient) (*ethTypes.Receipt, error) {
+		return cl.TransactionReceipt(ctx, txHash)
+	})
+}
+
+func (c *MultiClient) TransactionCount(ctx context.Context, blockHash common.Hash) (uint, error) {
+	return endpoint.DoWith(c.pool, isTransportError, func(cl IClient) (uint, error) {
+		return cl.TransactionCount(ctx, blockHash)
+	})
+}
+
+func (c *MultiClient) TransactionInBlock(ctx context.Context, blockHash common.Hash, index uint) (*ethTypes.Transaction, error) {
+	return endpoint.DoWith(c.pool, isTransportError, func(cl IClient) (*ethTypes.Transaction, error) {
+		return cl.TransactionInBlock(ctx, blockHash, index)
+	})
+}
+
+// SendTransaction broadcasts the signed transaction, it is resent to another endpoint only when the
+// endpoint could not be reached, resending keeps the same hash
+func (c *MultiClient) SendTransaction(ctx context.Context, tx *ethTypes.Transaction) error {
+	_, err := endpoint.DoWith(c.pool, isTransportError, func(cl IClient) (struct{}, error) {
+		return struct{}{}, cl.SendTransaction(ctx, tx)
+	})
+	return err
+}
+
+func (c *MultiClient) ParseMessage(log ethTypes.Log) (*bridgeContract.AbiMessage, error) {
+	return c.pool.Best().ParseMessage(log)
+}
+
+// SendMessage and ReceiveMessage are not sent again to another endpoint on an execution or nonce error,
+// the next endpoint would answer the same
+func (c *MultiClient) SendMessage(opts *bind.TransactOpts, _to string, _svc string, _sn *big.Int, _msg []byte) (*ethTypes.Transaction, error) {
+	return endpoint.DoWith(c.pool, isTransportError, func(cl IClient) (*ethTypes.Transaction, error) {
+		return cl.SendMessage(opts, _to, _svc, _sn, _msg)
+	})
+}
+
+func (c *MultiClient) ReceiveMessage(opts *bind.TransactOpts, srcNID string, sn *big.Int, msg []byte) (*ethTypes.Transaction, error) {
+	return endpoint.DoWith(c.pool, isTransportError, func(cl IClient) (*ethTypes.Transaction, error) {
+		return cl.ReceiveMessage(opts, srcNID, sn, msg)
+	})
+}
+
+func (c *MultiClient) MessageReceived(opts *bind.CallOpts, srcNetwork string, _connSn *big.Int) (bool, error) {
+	return endpoint.DoWith(c.pool, isTransportError, func(cl IClient) (bool, error) {
+		return cl.MessageReceived(opts, srcNetwork, _connSn)
+	})
+}
+
+func (c *MultiClient) ConnSn(opts *bind.CallOpts) (*big.Int, error) {
+	return endpoint.DoWith(c.pool, isTransportError, func(cl IClient) (*big.Int, error) {
+		return cl.ConnSn(opts)
+	})
+}
+
+// isTransportError adds the http errors of an overloaded or failing endpoint to the transport errors
+func isTransportError(err error) bool {
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests || httpErr.StatusCode >= http.StatusInternalServerError
+	}
+	return endpoint.IsTransportError(err)
+}
@@ -0,0 +1,52 @@
+package evm
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"testing"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/icon-project/centralized-relay/relayer/endpoint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// callClient answers eth_call with err
+type callClient struct {
+	IClient
+	err   error
+	calls int
+}
+
+func (c *callClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	c.calls++
+	return []byte{1}, c.err
+}
+
+func TestMultiClientFailover(t *testing.T) {
+	a, b := &callClient{err: errors.New("execution reverted")}, &callClient{}
+	pool, err := endpoint.NewPool(zap.NewNop(), "0x13881.mumbai",
+		&endpoint.Endpoint[IClient]{URL: "http://a", Client: a},
+		&endpoint.Endpoint[IClient]{URL: "http://b", Client: b},
+	)
+	require.NoError(t, err)
+	c := &MultiClient{log: zap.NewNop(), pool: pool}
+
+	// a revert is the answer of a healthy endpoint, it is neither tried elsewhere nor counted as failure
+	_, err = c.CallContract(context.Background(), ethereum.CallMsg{}, nil)
+	assert.ErrorContains(t, err, "execution reverted")
+	assert.Zero(t, b.calls)
+	for _, h := range c.Health() {
+		assert.Zero(t, h.Failures)
+	}
+
+	// an overloaded endpoint is failed over
+	a.err = rpc.HTTPError{StatusCode: http.StatusTooManyRequests}
+	_, err = c.CallContract(context.Background(), ethereum.CallMsg{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, b.calls)
+}
@@ -16,6 +16,8 @@ import (
 	ethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/icon-project/centralized-relay/relayer/provider"
+	"github.com/icon-project/centralized-relay/relayer/signer"
+	"github.com/icon-project/centralized-relay/relayer/wallet"
 
 	"go.uber.org/zap"
 )
@@ -23,18 +25,28 @@ import (
 var _ provider.ProviderConfig = &EVMProviderConfig{}
 
 type EVMProviderConfig struct {
-	ChainName       string `json:"-" yaml:"-"`
-	RPCUrl          string `json:"rpc-url" yaml:"rpc-url"`
-	VerifierRPCUrl  string `json:"verifier-rpc-url" yaml:"verifier-rpc-url"`
-	StartHeight     uint64 `json:"start-height" yaml:"start-height"`
-	Keystore        string `json:"keystore" yaml:"keystore"`
-	Password        string `json:"password" yaml:"password"`
-	GasPrice        int64  `json:"gas-price" yaml:"gas-price"`
-	GasLimit        uint64 `json:"gas-limit" yaml:"gas-limit"`
-	ContractAddress string `json:"contract-address" yaml:"contract-address"`
-	Concurrency     uint64 `json:"concurrency" yaml:"concurrency"`
-	FinalityBlock   uint64 `json:"finality-block" yaml:"finality-block"`
-	NID             string `json:"nid" yaml:"nid"`
+	ChainName       string   `json:"-" yaml:"-"`
+	RPCUrl          string   `json:"rpc-url" yaml:"rpc-url"`
+	RPCUrls         []string `json:"rpc-urls" yaml:"rpc-urls"`
+	VerifierRPCUrl  string   `json:"verifier-rpc-url" yaml:"verifier-rpc-url"`
+	WebsocketUrl    string   `json:"websocket-url" yaml:"websocket-url"`
+	StartHeight     uint64   `json:"start-height" yaml:"start-height"`
+	Keystore        string   `json:"keystore" yaml:"keystore"`
+	Password        string   `json:"password" yaml:"password"`
+	GasPrice        int64    `json:"gas-price" yaml:"gas-price"`
+	GasLimit        uint64   `json:"gas-limit" yaml:"gas-limit"`
+	ContractAddress string   `json:"contract-address" yaml:"contract-address"`
+	Concurrency     uint64   `json:"concurrency" yaml:"concurrency"`
+	FinalityBlock   uint64   `json:"finality-block" yaml:"finality-block"`
+	CatchUpWindow   uint64   `json:"catch-up-window" yaml:"catch-up-window"`
+	NID             string   `json:"nid" yaml:"nid"`
+	// Denom is the native coin the balances are reported in, eth when not set
+	Denom string `json:"denom,omitempty" yaml:"denom,omitempty"`
+	// Signer replaces the keystore with a remote or hsm signer
+	Signer *signer.Config `json:"signer,omitempty" yaml:"signer,omitempty"`
+	// Wallets submit along with the keystore, each one has its own nonce lane
+	Wallets        []wallet.Key    `json:"wallets,omitempty" yaml:"wallets,omitempty"`
+	WalletStrategy wallet.Strategy `json:"wallet-strategy,omitempty" yaml:"wallet-strategy,omitempty"`
 }
 
 type EVMProvider struct {
@@ -44,14 +56,17 @@ type EVMProvider struct {
 	cfg         *EVMProviderConfig
 	StartHeight uint64
 	blockReq    ethereum.FilterQuery
-	wallet      *keystore.Key
+	// wallets are loaded by Init, the primary one is the keystore or signer of the config
+	wallets *wallet.Pool
+	// logWindow is the current adaptive eth_getLogs window of the catch-up
+	logWindow uint64
 }
 
 func (p *EVMProviderConfig) NewProvider(log *zap.Logger, homepath string, debug bool, chainName string) (provider.ChainProvider, error) {
 	if err := p.Validate(); err != nil {
 		return nil, err
 	}
-	client, err := newClient(p.RPCUrl, p.ContractAddress, log)
+	client, err := newMultiClient(p.Endpoints(), p.ContractAddress, p.NID, log)
 	if err != nil {
 		return nil, fmt.Errorf("error occured when creating client: %v", err)
 	}
@@ -72,6 +87,9 @@ func (p *EVMProviderConfig) NewProvider(log *zap.Logger, homepath string, debug
 	if p.FinalityBlock == 0 {
 		p.FinalityBlock = uint64(DefaultFinalityBlock)
 	}
+	if p.Denom == "" {
+		p.Denom = DefaultDenom
+	}
 	p.ChainName = chainName
 
 	return &EVMProvider{
@@ -87,25 +105,93 @@ func (p *EVMProvider) NID() string {
 	return p.cfg.NID
 }
 
+func (p *EVMProviderConfig) GetNID() string {
+	return p.NID
+}
+
+// Endpoints returns rpc-url followed by the additional rpc-urls without duplicates
+func (p *EVMProviderConfig) Endpoints() []string {
+	return provider.Endpoints(p.RPCUrl, p.RPCUrls)
+}
+
+// maxGasLimit is the highest gas limit accepted in the config, above any block gas limit of the supported chains
+const maxGasLimit = 30_000_000
+
+// Validate reports every problem of the config which is found without reading the keystores
 func (p *EVMProviderConfig) Validate() error {
-	// TODO:
-	// add right validation
-	// Contract address check
-	// gas limit mandatory
-	// keystore
+	var errs provider.ConfigErrors
+	if p.NID == "" {
+		errs.Add("nid is required")
+	}
+
+	errs.AddErr(provider.ValidateEndpoints("rpc-url", p.Endpoints(), "http", "https"))
+	if p.VerifierRPCUrl != "" {
+		errs.AddErr(provider.ValidateURL("verifier-rpc-url", p.VerifierRPCUrl, "http", "https"))
+	}
+	if p.WebsocketUrl != "" {
+		errs.AddErr(provider.ValidateURL("websocket-url", p.WebsocketUrl, "ws", "wss"))
+	}
+
+	if !common.IsHexAddress(p.ContractAddress) {
+		errs.Add("contract-address %q is not a valid evm address", p.ContractAddress)
+	}
+
+	if p.GasPrice < 0 {
+		errs.Add("gas-price must not be negative")
+	}
+	if p.GasLimit > maxGasLimit {
+		errs.Add("gas-limit %d is above the maximum of %d", p.GasLimit, maxGasLimit)
+	}
+
+	errs.AddErr(wallet.ValidateKeys(p.Keys(), p.WalletStrategy))
+	return errs.Err()
+}
+
+// ValidateKeystores checks that every keystore opens with its password, each of them is decrypted
+func (p *EVMProviderConfig) ValidateKeystores() error {
+	return wallet.ValidateKeystores(p.Keys(), decryptKeystore)
+}
+
+// Keys returns the key of the config followed by the additional wallets
+func (p *EVMProviderConfig) Keys() []wallet.Key {
+	return append([]wallet.Key{{Keystore: p.Keystore, Password: p.Password, Signer: p.Signer}}, p.Wallets...)
+}
+
+func (p *EVMProvider) Init(ctx context.Context) error {
+	pool, err := wallet.Load(ctx, p.cfg.Keys(), p.cfg.WalletStrategy, decryptKeystore, signerAddress)
+	if err != nil {
+		return fmt.Errorf("failed to load evm %w", err)
+	}
+	p.wallets = pool
 	return nil
 }
 
-func (p *EVMProvider) Init(context.Context) error {
-	wallet, err := RestoreKey(p.cfg.Keystore, p.cfg.Password)
+// decryptKeystore returns the private key of an evm keystore
+func decryptKeystore(data []byte, password string) ([]byte, error) {
+	key, err := keystore.DecryptKey(data, password)
 	if err != nil {
-		return fmt.Errorf("failed to restore evm wallet %v", err)
+		return nil, err
 	}
-	p.wallet = wallet
+	defer signer.Zero(key.PrivateKey.D.Bits())
+	return crypto.FromECDSA(key.PrivateKey), nil
+}
 
-	//
+// signerAddress returns the evm address of the public key of a signer
+func signerAddress(publicKey []byte) (string, error) {
+	key, err := crypto.UnmarshalPubkey(publicKey)
+	if err != nil {
+		return "", err
+	}
+	return crypto.PubkeyToAddress(*key).Hex(), nil
+}
 
-	return nil
+func (p *EVMProvider) Close() error {
+	return p.wallets.Close()
+}
+
+// Wallets returns the wallet pool loaded by Init
+func (p *EVMProvider) Wallets() *wallet.Pool {
+	return p.wallets
 }
 
 func (p *EVMProvider) Type() string {
@@ -120,8 +206,11 @@ func (p *EVMProvider) ChainName() string {
 	return p.cfg.ChainName
 }
 
-func (p *EVMProvider) Wallet() *keystore.Key {
-	return p.wallet
+func (p *EVMProvider) GetWalletAddress() (string, error) {
+	if p.wallets == nil {
+		return "", fmt.Errorf("evm signer is not loaded")
+	}
+	return p.wallets.Primary().Address, nil
 }
 
 func (p *EVMProvider) FinalityBlock(ctx context.Context) uint64 {
@@ -190,33 +279,50 @@ func (r *EVMProvider) transferBalance(senderKey, recepientAddress string, amount
 	return
 }
 
+// GetTransationOpts returns the options of a transaction of the primary wallet
 func (p *EVMProvider) GetTransationOpts(ctx context.Context) (*bind.TransactOpts, error) {
-	newTransactOpts := func(w *keystore.Key) (*bind.TransactOpts, error) {
-		txo, err := bind.NewKeyedTransactorWithChainID(w.PrivateKey, p.client.GetChainID())
-		if err != nil {
-			return nil, err
+	if p.wallets == nil {
+		return nil, fmt.Errorf("evm signer is not loaded")
+	}
+	return p.transactOpts(ctx, p.wallets.Primary())
+}
+
+// transactOpts returns the options of the next transaction of the wallet, the nonce is taken from its lane
+func (p *EVMProvider) transactOpts(ctx context.Context, w *wallet.Wallet) (*bind.TransactOpts, error) {
+	from := common.HexToAddress(w.Address)
+	newTransactOpts := func(s signer.Signer) (*bind.TransactOpts, error) {
+		txSigner := types.LatestSignerForChainID(p.client.GetChainID())
+		txo := &bind.TransactOpts{
+			From: from,
+			Signer: func(addr common.Address, tx *types.Transaction) (*types.Transaction, error) {
+				if addr != from {
+					return nil, bind.ErrNotAuthorized
+				}
+				sig, err := s.Sign(ctx, txSigner.Hash(tx).Bytes())
+				if err != nil {
+					return nil, err
+				}
+				return tx.WithSignature(txSigner, sig)
+			},
 		}
-		ctx, cancel := context.WithTimeout(context.Background(), defaultReadTimeout)
+		gasCtx, cancel := context.WithTimeout(context.Background(), defaultReadTimeout)
 		defer cancel()
-		txo.GasPrice, err = p.client.SuggestGasPrice(ctx)
+		// the transactor suggests the gas price itself when the query fails
+		txo.GasPrice, _ = p.client.SuggestGasPrice(gasCtx)
 		return txo, nil
 	}
 
-	h, err := p.QueryLatestHeight(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	non, err := p.client.NonceAt(ctx, p.wallet.Address, big.NewInt(int64(h)))
+	// the pending nonce counts the transactions in the pool of the node, the latest one would hand them out again
+	non, err := p.client.PendingNonceAt(ctx, from)
 	if err != nil {
 		return nil, err
 	}
 
-	txOpts, err := newTransactOpts(p.wallet)
+	txOpts, err := newTransactOpts(w.Signer)
 	if err != nil {
 		return nil, err
 	}
-	txOpts.Nonce = big.NewInt(int64(non))
+	txOpts.Nonce = new(big.Int).SetUint64(w.NextNonce(non))
 	txOpts.Context = ctx
 	if p.cfg.GasPrice > 0 {
 		txOpts.GasPrice = big.NewInt(p.cfg.GasPrice)
@@ -8,7 +8,9 @@ import (
 	"testing"
 
 	"github.com/icon-project/centralized-relay/relayer/events"
+	"github.com/icon-project/centralized-relay/relayer/signer"
 	providerTypes "github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/icon-project/centralized-relay/relayer/wallet"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
 )
@@ -43,9 +45,11 @@ func TestTransferBalance(t *testing.T) {
 	pro, err := MockEvmProvider("0x0165878A594ca255338adfa4d48449f69242Eb8F")
 	assert.NoError(t, err)
 
+	addr, err := pro.GetWalletAddress()
+	assert.NoError(t, err)
 	txhash, err := pro.transferBalance(
 		"ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80",
-		pro.wallet.Address.Hex(), big.NewInt(100_000_000_000_000_000_0))
+		addr, big.NewInt(100_000_000_000_000_000_0))
 
 	assert.NoError(t, err)
 
@@ -137,3 +141,48 @@ func TestEventLogReceived(t *testing.T) {
 	}
 
 }
+
+func TestValidate(t *testing.T) {
+	cfg := EVMProviderConfig{
+		NID:             "0x13881.mumbai",
+		RPCUrl:          "https://rpc-mumbai.maticvigil.com",
+		WebsocketUrl:    "wss://rpc-mumbai.maticvigil.com/ws",
+		Keystore:        testKeyStore,
+		Password:        testKeyPassword,
+		GasLimit:        431877,
+		ContractAddress: "0x0165878A594ca255338adfa4d48449f69242Eb8F",
+	}
+	assert.NoError(t, cfg.Validate())
+
+	invalid := EVMProviderConfig{
+		RPCUrls:         []string{"ftp://rpc-mumbai.maticvigil.com"},
+		WebsocketUrl:    "https://rpc-mumbai.maticvigil.com",
+		Keystore:        testKeyStore,
+		Password:        "wrong",
+		GasPrice:        -1,
+		GasLimit:        maxGasLimit + 1,
+		ContractAddress: "cx7bd6ad0ad8269bcc4b980c3025b349623fdd900e",
+		Wallets:         []wallet.Key{{Keystore: testKeyStore, Password: "wrong"}},
+		WalletStrategy:  "random",
+	}
+	err := invalid.Validate()
+	assert.Error(t, err)
+	for _, problem := range []string{"nid", "rpc-url", "websocket-url", "contract-address", "gas-price", "gas-limit", "wallet-strategy"} {
+		assert.ErrorContains(t, err, problem)
+	}
+
+	// the passwords are only checked by decrypting the keystores
+	assert.NoError(t, cfg.ValidateKeystores())
+	err = invalid.ValidateKeystores()
+	for _, problem := range []string{"keystore " + testKeyStore, "wallets[0].keystore"} {
+		assert.ErrorContains(t, err, problem)
+	}
+
+	// no keystore is needed with a remote signer
+	remote := cfg
+	remote.Keystore, remote.Password = "", ""
+	remote.Signer = &signer.Config{Type: signer.TypeRemote, Remote: &signer.RemoteConfig{URL: "http://127.0.0.1:5184", KeyID: "relayer"}}
+	assert.NoError(t, remote.Validate())
+	remote.Signer.Remote.KeyID = ""
+	assert.ErrorContains(t, remote.Validate(), "key-id")
+}
@@ -2,10 +2,16 @@ package evm
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
+	"slices"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	evmtypes "github.com/icon-project/centralized-relay/relayer/chains/evm/types"
+	"github.com/icon-project/centralized-relay/relayer/provider"
 	"github.com/icon-project/centralized-relay/relayer/types"
 	providerTypes "github.com/icon-project/centralized-relay/relayer/types"
 )
@@ -19,8 +25,12 @@ func (p *EVMProvider) QueryLatestHeight(ctx context.Context) (height uint64, err
 }
 
 func (p *EVMProvider) QueryBalance(ctx context.Context, addr string) (*providerTypes.Coin, error) {
-	//TODO:
-	return nil, nil
+	balance, err := p.client.GetBalance(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	coin := providerTypes.NewCoin(p.cfg.Denom, balance, 18)
+	return &coin, nil
 }
 
 func (p *EVMProvider) ShouldReceiveMessage(ctx context.Context, messagekey types.Message) (bool, error) {
@@ -35,17 +45,46 @@ func (p *EVMProvider) MessageReceived(ctx context.Context, messageKey types.Mess
 	return p.client.MessageReceived(nil, messageKey.Src, big.NewInt(int64(messageKey.Sn)))
 }
 
-// func (p *EVMProvider) QueryBalance(ctx context.Context, addr string) (*types.Coin, error) {
-// 	balance, err := p.client.GetBalance(ctx, addr)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-// 	return &types.Coin{Amount: balance.Uint64(), Denom: "eth"}, nil
-// }
-
-// TODO: may not be need anytime soon so its ok to implement later on
-func (ip *EVMProvider) GenerateMessage(ctx context.Context, key *providerTypes.MessageKeyWithMessageHeight) (*providerTypes.Message, error) {
-	return nil, nil
+// GenerateMessage parses the message of the key from the contract logs of the block at its height
+func (p *EVMProvider) GenerateMessage(ctx context.Context, key *providerTypes.MessageKeyWithMessageHeight) (*providerTypes.Message, error) {
+	if key == nil {
+		return nil, errors.New("GenerateMessage: message key cannot be nil")
+	}
+	height := new(big.Int).SetUint64(key.MsgHeight)
+	query := getEventFilterQuery(p.cfg.ContractAddress)
+	query.FromBlock, query.ToBlock = height, height
+	logs, err := p.client.FilterLogs(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("GenerateMessage: %w", err)
+	}
+	for _, log := range logs {
+		message, err := p.getRelayMessageFromLog(log)
+		if err != nil {
+			return nil, fmt.Errorf("GenerateMessage: %w", err)
+		}
+		if message.Sn == key.Sn {
+			return message, nil
+		}
+	}
+	return nil, fmt.Errorf("GenerateMessage: sn %d not found at height %d", key.Sn, key.MsgHeight)
+}
+
+var _ provider.SnQuerier = (*EVMProvider)(nil)
+
+// QueryConnSn returns the sn of the connection contract at height, 0 before the contract is deployed
+func (p *EVMProvider) QueryConnSn(ctx context.Context, height uint64) (uint64, error) {
+	opts := &bind.CallOpts{Context: ctx}
+	if height > 0 {
+		opts.BlockNumber = new(big.Int).SetUint64(height)
+	}
+	sn, err := p.client.ConnSn(opts)
+	if errors.Is(err, bind.ErrNoCode) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("QueryConnSn: %w", err)
+	}
+	return sn.Uint64(), nil
 }
 
 func (icp *EVMProvider) QueryTransactionReceipt(ctx context.Context, txHash string) (*types.Receipt, error) {
@@ -65,3 +104,35 @@ func (icp *EVMProvider) QueryTransactionReceipt(ctx context.Context, txHash stri
 
 	return &finalizedReceipt, nil
 }
+
+var (
+	_ provider.Rescanner       = (*EVMProvider)(nil)
+	_ provider.TxMessageFinder = (*EVMProvider)(nil)
+)
+
+// TxMessages parses the messages emitted by the connection in the transaction txHash,
+// the logs are checked against the verifier rpc when one is configured
+func (p *EVMProvider) TxMessages(ctx context.Context, txHash string) ([]*types.Message, error) {
+	receipt, err := p.client.TransactionReceipt(ctx, common.HexToHash(txHash))
+	if err != nil {
+		return nil, fmt.Errorf("TxMessages: %w", err)
+	}
+	if receipt.Status != ethTypes.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("transaction %s failed", txHash)
+	}
+
+	var logs []ethTypes.Log
+	for _, log := range receipt.Logs {
+		if log.Address == common.HexToAddress(p.cfg.ContractAddress) && len(log.Topics) > 0 && slices.Contains(MonitorEvents, log.Topics[0]) {
+			logs = append(logs, *log)
+		}
+	}
+	messages, quarantined, err := p.FindMessages(ctx, &evmtypes.BlockNotification{Height: receipt.BlockNumber, Logs: logs})
+	if err != nil {
+		return nil, fmt.Errorf("TxMessages: %w", err)
+	}
+	if len(quarantined) > 0 {
+		return nil, fmt.Errorf("%d messages of transaction %s rejected by the verifier rpc", len(quarantined), txHash)
+	}
+	return messages, nil
+}
@@ -2,11 +2,13 @@ package evm
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/icon-project/centralized-relay/relayer/events"
 	providerTypes "github.com/icon-project/centralized-relay/relayer/types"
 	"go.uber.org/zap"
@@ -15,22 +17,62 @@ import (
 // this will be executed in go route
 func (p *EVMProvider) Route(ctx context.Context, message *providerTypes.Message, callback providerTypes.TxResponseFunc) error {
 	p.log.Info("starting to route message", zap.Any("message", message))
+	if p.wallets == nil {
+		return fmt.Errorf("routing failed: evm signer is not loaded")
+	}
 
-	opts, err := p.GetTransationOpts(ctx)
+	w := p.wallets.Acquire()
+	opts, err := p.transactOpts(ctx, w)
 	if err != nil {
+		w.Release(err)
 		return fmt.Errorf("routing failed: %w", err)
 	}
 
 	messageKey := message.MessageKey()
+	nonce := opts.Nonce.Uint64()
 
+	// the transaction is built and signed before it is broadcast, a failure of either leaves the nonce unused
+	opts.NoSend = true
 	tx, err := p.SendTransaction(ctx, opts, message)
+	if err == nil {
+		if err = p.client.SendTransaction(ctx, tx); err != nil && !rejected(err) {
+			// the transaction may have reached the node, the nonce stays taken
+			w.Release(err)
+			return fmt.Errorf("routing failed: %w", err)
+		}
+	}
 	if err != nil {
+		if !w.RewindNonce(nonce) {
+			p.log.Warn("nonce of a failed transaction is not the last one of the wallet, it is left as a gap", zap.String("wallet", w.Address), zap.Uint64("nonce", nonce))
+		}
+		w.Release(err)
 		return fmt.Errorf("routing failed: %w", err)
 	}
-	p.WaitForTxResult(ctx, tx, messageKey, callback)
+	p.log.Debug("sent transaction", zap.String("wallet", w.Address), zap.Uint64("nonce", tx.Nonce()), zap.String("tx_hash", tx.Hash().String()))
+	p.WaitForTxResult(ctx, tx, messageKey, func(key providerTypes.MessageKey, res providerTypes.TxResponse, err error) {
+		w.Release(err)
+		if callback != nil {
+			callback(key, res, err)
+		}
+	})
 	return nil
 }
 
+// rejected tells whether the nodes answered the broadcast with an error, the transaction did not enter
+// their pool. A broadcast failing on a single endpoint for any other reason may have reached it
+func rejected(err error) bool {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, err := range joined.Unwrap() {
+			if !rejected(err) {
+				return false
+			}
+		}
+		return true
+	}
+	var rpcErr rpc.Error
+	return errors.As(err, &rpcErr)
+}
+
 func (p *EVMProvider) SendTransaction(ctx context.Context, opts *bind.TransactOpts, message *providerTypes.Message) (*types.Transaction, error) {
 	switch message.EventType {
 	// TODO: estimate and throw error if failed
@@ -0,0 +1,159 @@
+package evm
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/icon-project/centralized-relay/relayer/events"
+	"github.com/icon-project/centralized-relay/relayer/signer"
+	providerTypes "github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/icon-project/centralized-relay/relayer/wallet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// txClient answers the queries of the transaction options
+type txClient struct {
+	IClient
+	nonce uint64
+	// sendErr fails the broadcast of every transaction
+	sendErr error
+}
+
+func (c *txClient) ReceiveMessage(opts *bind.TransactOpts, srcNID string, sn *big.Int, msg []byte) (*ethTypes.Transaction, error) {
+	tx := ethTypes.NewTransaction(opts.Nonce.Uint64(), common.Address{}, big.NewInt(0), 100_000, opts.GasPrice, msg)
+	return opts.Signer(opts.From, tx)
+}
+
+func (c *txClient) SendTransaction(context.Context, *ethTypes.Transaction) error {
+	return c.sendErr
+}
+
+// rpcError is an error answered by a node
+type rpcError string
+
+func (e rpcError) Error() string  { return string(e) }
+func (e rpcError) ErrorCode() int { return -32000 }
+
+func (c *txClient) GetChainID() *big.Int {
+	return big.NewInt(43113)
+}
+
+func (c *txClient) SuggestGasPrice(context.Context) (*big.Int, error) {
+	return big.NewInt(25_000_000_000), nil
+}
+
+func (c *txClient) PendingNonceAt(context.Context, common.Address) (uint64, error) {
+	return c.nonce, nil
+}
+
+func TestRemoteSigner(t *testing.T) {
+	key, err := RestoreKey(testKeyStore, testKeyPassword)
+	require.NoError(t, err)
+	local, err := signer.NewLocal(crypto.FromECDSA(key.PrivateKey))
+	require.NoError(t, err)
+	srv := httptest.NewServer(signer.NewServer(zap.NewNop(), map[string]signer.Signer{"relayer": local}, ""))
+	defer srv.Close()
+
+	client := &txClient{nonce: 7}
+	p := &EVMProvider{
+		client: client,
+		log:    zap.NewNop(),
+		cfg: &EVMProviderConfig{
+			Signer: &signer.Config{
+				Type:   signer.TypeRemote,
+				Remote: &signer.RemoteConfig{URL: srv.URL, KeyID: "relayer"},
+			},
+		},
+	}
+	ctx := context.Background()
+	require.NoError(t, p.Init(ctx))
+	addr, err := p.GetWalletAddress()
+	require.NoError(t, err)
+	assert.Equal(t, expectedAddr, addr)
+
+	opts, err := p.GetTransationOpts(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), opts.Nonce.Int64())
+
+	tx := ethTypes.NewTransaction(opts.Nonce.Uint64(), common.HexToAddress("0x0165878A594ca255338adfa4d48449f69242Eb8F"), big.NewInt(0), 100_000, opts.GasPrice, nil)
+	signed, err := opts.Signer(opts.From, tx)
+	require.NoError(t, err)
+	sender, err := ethTypes.Sender(ethTypes.LatestSignerForChainID(client.GetChainID()), signed)
+	require.NoError(t, err)
+	assert.Equal(t, expectedAddr, sender.Hex())
+
+	_, err = opts.Signer(common.HexToAddress("0x0165878A594ca255338adfa4d48449f69242Eb8F"), tx)
+	assert.Error(t, err)
+}
+
+func TestWalletPool(t *testing.T) {
+	data, addr, err := NewKeystore(nil, "second")
+	require.NoError(t, err)
+	second := filepath.Join(t.TempDir(), "second.json")
+	require.NoError(t, os.WriteFile(second, data, 0o600))
+
+	cfg := &EVMProviderConfig{
+		Keystore:       testKeyStore,
+		Password:       testKeyPassword,
+		Wallets:        []wallet.Key{{Keystore: second, Password: "second"}},
+		WalletStrategy: wallet.LeastInFlight,
+	}
+	p := &EVMProvider{client: &txClient{nonce: 3}, log: zap.NewNop(), cfg: cfg}
+	ctx := context.Background()
+	require.NoError(t, p.Init(ctx))
+	defer p.Close()
+
+	primary, err := p.GetWalletAddress()
+	require.NoError(t, err)
+	assert.Equal(t, expectedAddr, primary)
+
+	// every wallet has its own nonce lane
+	first, next := p.wallets.Acquire(), p.wallets.Acquire()
+	assert.Equal(t, expectedAddr, first.Address)
+	assert.True(t, strings.EqualFold(addr, next.Address))
+	for _, w := range []*wallet.Wallet{first, next, first} {
+		opts, err := p.transactOpts(ctx, w)
+		require.NoError(t, err)
+		assert.Equal(t, w.Address, opts.From.Hex())
+	}
+	assert.Equal(t, uint64(5), *first.Stats().Nonce)
+	assert.Equal(t, uint64(4), *next.Stats().Nonce)
+
+	cfg.Wallets[0].Password = "wrong"
+	assert.Error(t, (&EVMProvider{client: &txClient{}, log: zap.NewNop(), cfg: cfg}).Init(ctx))
+}
+
+func TestRouteNonce(t *testing.T) {
+	client := &txClient{nonce: 3}
+	p := &EVMProvider{client: client, log: zap.NewNop(), cfg: &EVMProviderConfig{Keystore: testKeyStore, Password: testKeyPassword}}
+	ctx := context.Background()
+	require.NoError(t, p.Init(ctx))
+	defer p.Close()
+	w := p.wallets.Primary()
+	message := &providerTypes.Message{Src: "0x2.icon", Dst: "avalanche", Sn: 1, EventType: events.EmitMessage}
+
+	// a transaction rejected by the node leaves its nonce to the next one
+	client.sendErr = rpcError("insufficient funds for gas * price + value")
+	assert.Error(t, p.Route(ctx, message, nil))
+	assert.Equal(t, uint64(3), *w.Stats().Nonce)
+
+	// a broadcast which may have reached the node keeps its nonce
+	client.sendErr = context.DeadlineExceeded
+	assert.Error(t, p.Route(ctx, message, nil))
+	assert.Equal(t, uint64(4), *w.Stats().Nonce)
+	client.sendErr = errors.Join(rpcError("nonce too low"), context.DeadlineExceeded)
+	assert.Error(t, p.Route(ctx, message, nil))
+	assert.Equal(t, uint64(5), *w.Stats().Nonce)
+}
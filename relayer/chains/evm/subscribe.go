@@ -0,0 +1,143 @@
+package evm
+
+import (
+	"context"
+	"time"
+
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	relayertypes "github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// wsReconnectInterval is the interval between websocket reconnect attempts while polling
+	wsReconnectInterval = 10 * time.Second
+)
+
+// subscriptionListener follows the chain through eth_subscribe to newHeads and the bridge contract logs.
+// while the websocket is down it polls the rpc and on reconnect the missed blocks are backfilled
+func (r *EVMProvider) subscriptionListener(ctx context.Context, startHeight uint64, blockInfoChan chan relayertypes.BlockInfo) error {
+	next := startHeight
+	for {
+		var err error
+		next, err = r.subscribe(ctx, next, blockInfoChan)
+		if ctx.Err() != nil {
+			return nil
+		}
+		r.log.Warn("evm websocket subscription failed, falling back to polling", zap.Error(err))
+
+		next = r.pollUntilReconnect(ctx, next, blockInfoChan)
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+// subscribe relays blocks from the websocket subscription starting at next
+// and returns the next height to process once the subscription fails
+func (r *EVMProvider) subscribe(ctx context.Context, next uint64, blockInfoChan chan relayertypes.BlockInfo) (uint64, error) {
+	ws, err := ethclient.DialContext(ctx, r.cfg.WebsocketUrl)
+	if err != nil {
+		return next, errors.Wrap(err, "dial websocket")
+	}
+	defer ws.Close()
+
+	heads := make(chan *ethTypes.Header, 16)
+	headSub, err := ws.SubscribeNewHead(ctx, heads)
+	if err != nil {
+		return next, errors.Wrap(err, "subscribe newHeads")
+	}
+	defer headSub.Unsubscribe()
+
+	logs := make(chan ethTypes.Log, 256)
+	query := r.blockReq
+	query.FromBlock, query.ToBlock = nil, nil
+	logSub, err := ws.SubscribeFilterLogs(ctx, query, logs)
+	if err != nil {
+		return next, errors.Wrap(err, "subscribe logs")
+	}
+	defer logSub.Unsubscribe()
+
+	// backfill the blocks produced before the subscriptions started,
+	// logs of these blocks received from the subscription are dropped
+	head, err := ws.BlockNumber(ctx)
+	if err != nil {
+		return next, errors.Wrap(err, "websocket BlockNumber")
+	}
+	if next, err = r.catchUp(ctx, next, head, blockInfoChan); err != nil {
+		return next, err
+	}
+	r.log.Info("evm websocket subscription started", zap.Uint64("next-height", next))
+
+	pending := make(map[uint64][]ethTypes.Log)
+	for {
+		select {
+		case <-ctx.Done():
+			return next, ctx.Err()
+		case err := <-headSub.Err():
+			return next, errors.Wrap(err, "newHeads subscription")
+		case err := <-logSub.Err():
+			return next, errors.Wrap(err, "logs subscription")
+		case log := <-logs:
+			if log.BlockNumber < next {
+				continue
+			}
+			if log.Removed {
+				pending[log.BlockNumber] = removeLog(pending[log.BlockNumber], log)
+				continue
+			}
+			pending[log.BlockNumber] = append(pending[log.BlockNumber], log)
+		case header := <-heads:
+			// logs of the new head may still be in flight, so blocks up to its parent are relayed
+			height := header.Number.Uint64()
+			for ; next < height; next++ {
+				blockLogs := pending[next]
+				delete(pending, next)
+				if len(blockLogs) == 0 && next+1 < height {
+					continue
+				}
+				if err := r.relayBlock(ctx, next, blockLogs, blockInfoChan); err != nil {
+					return next, err
+				}
+			}
+		}
+	}
+}
+
+// pollUntilReconnect catches up through the rpc until the websocket can be subscribed again
+func (r *EVMProvider) pollUntilReconnect(ctx context.Context, next uint64, blockInfoChan chan relayertypes.BlockInfo) uint64 {
+	pollTicker := time.NewTicker(BlockInterval)
+	defer pollTicker.Stop()
+	reconnect := time.After(wsReconnectInterval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return next
+		case <-reconnect:
+			return next
+		case <-pollTicker.C:
+			latest := r.latestHeight()
+			if latest == 0 {
+				continue
+			}
+			var err error
+			if next, err = r.catchUp(ctx, next, latest, blockInfoChan); err != nil {
+				r.log.Error("evm listener: failed to catch up", zap.Uint64("next-height", next), zap.Error(err))
+			}
+		}
+	}
+}
+
+func removeLog(logs []ethTypes.Log, removed ethTypes.Log) []ethTypes.Log {
+	kept := logs[:0]
+	for _, log := range logs {
+		if log.TxHash == removed.TxHash && log.Index == removed.Index {
+			continue
+		}
+		kept = append(kept, log)
+	}
+	return kept
+}
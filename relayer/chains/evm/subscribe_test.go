@@ -0,0 +1,16 @@
+package evm
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoveLog(t *testing.T) {
+	tx := common.HexToHash("0x01")
+	logs := []ethTypes.Log{{TxHash: tx, Index: 0}, {TxHash: tx, Index: 1}}
+	kept := removeLog(logs, ethTypes.Log{TxHash: tx, Index: 0, Removed: true})
+	assert.Equal(t, []ethTypes.Log{{TxHash: tx, Index: 1}}, kept)
+}
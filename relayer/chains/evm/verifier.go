@@ -0,0 +1,143 @@
+package evm
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/icon-project/centralized-relay/relayer/chains/evm/types"
+	relayertypes "github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrVerificationMismatch = errors.New("verifier rpc mismatch")
+	ErrVerifierUnavailable  = errors.New("verifier rpc unavailable")
+)
+
+// verifierRetryInterval is the wait between the attempts of a verifier request and the first wait
+// before a block which could not be verified is tried again
+var verifierRetryInterval = BlockInterval
+
+// maxVerifierBackoff bounds the wait before a block which could not be verified is tried again
+const maxVerifierBackoff = time.Minute
+
+// verifyEnabled is true when a verifier rpc separate from the primary rpc is configured
+func (p *EVMProvider) verifyEnabled() bool {
+	return p.verifier != nil && p.verifier != p.client
+}
+
+// verifyLogs cross-checks the logs of a single block against the verifier rpc.
+// mismatches are returned per log, rpc failures of the verifier are returned as error
+func (p *EVMProvider) verifyLogs(ctx context.Context, height uint64, logs []ethTypes.Log) ([]error, error) {
+	mismatches := make([]error, len(logs))
+	if len(logs) == 0 {
+		return mismatches, nil
+	}
+
+	header, err := retryVerifier(ctx, func() (*ethTypes.Header, error) {
+		return p.verifier.GetHeaderByHeight(ctx, new(big.Int).SetUint64(height))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetHeaderByHeight %d: %v", ErrVerifierUnavailable, height, err)
+	}
+	blockHash := header.Hash()
+
+	query := p.blockReq
+	query.FromBlock, query.ToBlock = nil, nil
+	query.BlockHash = &blockHash
+	verifierLogs, err := retryVerifier(ctx, func() ([]ethTypes.Log, error) {
+		return p.verifier.FilterLogs(ctx, query)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: FilterLogs %d: %v", ErrVerifierUnavailable, height, err)
+	}
+
+	for i, log := range logs {
+		if log.BlockHash != blockHash {
+			mismatches[i] = fmt.Errorf("%w: block hash %s, verifier has %s", ErrVerificationMismatch, log.BlockHash, blockHash)
+			continue
+		}
+		mismatches[i] = matchLog(log, verifierLogs)
+	}
+	return mismatches, nil
+}
+
+// findMessagesUntilVerified is FindMessages for the listener, a block which cannot be verified because the
+// verifier rpc is unavailable is tried again with backoff, so the listener keeps running and does not skip it
+func (p *EVMProvider) findMessagesUntilVerified(ctx context.Context, lbn *types.BlockNotification) ([]*relayertypes.Message, []*relayertypes.Message, error) {
+	backoff := verifierRetryInterval
+	for {
+		messages, quarantined, err := p.FindMessages(ctx, lbn)
+		if !errors.Is(err, ErrVerifierUnavailable) {
+			return messages, quarantined, err
+		}
+		p.log.Warn("failed to verify block, retrying", zap.Uint64("height", lbn.Height.Uint64()),
+			zap.Duration("backoff", backoff), zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > maxVerifierBackoff {
+			backoff = maxVerifierBackoff
+		}
+	}
+}
+
+// matchLog checks that the log is present with the same content in the verifier logs
+func matchLog(log ethTypes.Log, verifierLogs []ethTypes.Log) error {
+	for _, v := range verifierLogs {
+		if v.TxHash != log.TxHash || v.Index != log.Index {
+			continue
+		}
+		switch {
+		case v.Address != log.Address:
+			return fmt.Errorf("%w: address %s, verifier has %s", ErrVerificationMismatch, log.Address, v.Address)
+		case !equalTopics(v.Topics, log.Topics):
+			return fmt.Errorf("%w: topics of tx %s differ", ErrVerificationMismatch, log.TxHash)
+		case !bytes.Equal(v.Data, log.Data):
+			return fmt.Errorf("%w: data of tx %s differs", ErrVerificationMismatch, log.TxHash)
+		case v.Removed != log.Removed:
+			return fmt.Errorf("%w: log of tx %s removed on verifier", ErrVerificationMismatch, log.TxHash)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: log %d of tx %s not found", ErrVerificationMismatch, log.Index, log.TxHash)
+}
+
+func equalTopics(a, b []common.Hash) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func retryVerifier[T any](ctx context.Context, fn func() (T, error)) (T, error) {
+	var (
+		result T
+		err    error
+	)
+	for i := 0; i < RPCCallRetry; i++ {
+		// the verifier may lag behind the primary rpc, so not found is retried as well
+		if result, err = fn(); err == nil {
+			return result, nil
+		}
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		case <-time.After(verifierRetryInterval):
+		}
+	}
+	return result, err
+}
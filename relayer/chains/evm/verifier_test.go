@@ -0,0 +1,116 @@
+package evm
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/icon-project/centralized-relay/relayer/chains/evm/types"
+	relayertypes "github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeVerifier serves a single block and its logs
+type fakeVerifier struct {
+	IClient
+	header *ethTypes.Header
+	logs   []ethTypes.Log
+}
+
+func (f *fakeVerifier) GetHeaderByHeight(ctx context.Context, height *big.Int) (*ethTypes.Header, error) {
+	if height.Cmp(f.header.Number) != 0 {
+		return nil, ethereum.NotFound
+	}
+	return f.header, nil
+}
+
+func (f *fakeVerifier) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]ethTypes.Log, error) {
+	if q.BlockHash == nil || *q.BlockHash != f.header.Hash() {
+		return nil, nil
+	}
+	return f.logs, nil
+}
+
+func TestVerifyLogs(t *testing.T) {
+	header := &ethTypes.Header{Number: big.NewInt(100), Difficulty: big.NewInt(0)}
+	log := ethTypes.Log{
+		Address:     common.HexToAddress("0x0165878A594ca255338adfa4d48449f69242Eb8F"),
+		Topics:      MonitorEvents,
+		Data:        []byte("message"),
+		BlockNumber: 100,
+		BlockHash:   header.Hash(),
+		TxHash:      common.HexToHash("0x01"),
+		Index:       1,
+	}
+	p := &EVMProvider{
+		verifier: &fakeVerifier{header: header, logs: []ethTypes.Log{log}},
+		blockReq: getEventFilterQuery(log.Address.Hex()),
+	}
+	require.True(t, p.verifyEnabled())
+
+	forged := log
+	forged.Data = []byte("forged")
+	wrongBlock := log
+	wrongBlock.BlockHash = common.HexToHash("0xdead")
+	missing := log
+	missing.Index = 2
+
+	mismatches, err := p.verifyLogs(context.Background(), 100, []ethTypes.Log{log, forged, wrongBlock, missing})
+	require.NoError(t, err)
+	require.Len(t, mismatches, 4)
+	assert.NoError(t, mismatches[0])
+	for _, err := range mismatches[1:] {
+		assert.True(t, errors.Is(err, ErrVerificationMismatch), err)
+	}
+}
+
+// flakyVerifier fails the first failures header requests
+type flakyVerifier struct {
+	fakeVerifier
+	failures int
+}
+
+func (f *flakyVerifier) GetHeaderByHeight(ctx context.Context, height *big.Int) (*ethTypes.Header, error) {
+	if f.failures > 0 {
+		f.failures--
+		return nil, errors.New("connection refused")
+	}
+	return f.fakeVerifier.GetHeaderByHeight(ctx, height)
+}
+
+func TestRelayBlockVerifierUnavailable(t *testing.T) {
+	defer func(interval time.Duration) { verifierRetryInterval = interval }(verifierRetryInterval)
+	verifierRetryInterval = time.Millisecond
+
+	client := &logClient{heights: []uint64{100}}
+	p := newCatchUpProvider(client, 100)
+	p.cfg.NID = "0x13881.mumbai"
+	logs, err := client.FilterLogs(context.Background(), ethereum.FilterQuery{FromBlock: big.NewInt(100), ToBlock: big.NewInt(100)})
+	require.NoError(t, err)
+	header := &ethTypes.Header{Number: big.NewInt(100), Difficulty: big.NewInt(0)}
+	// the verifier stays down for two rounds of retries
+	p.verifier = &flakyVerifier{fakeVerifier: fakeVerifier{header: header, logs: logs}, failures: 2*RPCCallRetry + 1}
+
+	_, _, err = p.FindMessages(context.Background(), &types.BlockNotification{Height: big.NewInt(100), Logs: logs})
+	require.ErrorIs(t, err, ErrVerifierUnavailable)
+
+	blockInfoChan := make(chan relayertypes.BlockInfo, 1)
+	require.NoError(t, p.relayBlock(context.Background(), 100, logs, blockInfoChan))
+	block := <-blockInfoChan
+	assert.Equal(t, uint64(100), block.Height)
+	require.Len(t, block.Messages, 1)
+	assert.Equal(t, uint64(1), block.Messages[0].Sn)
+	assert.Empty(t, block.Quarantined)
+
+	// the listener stops with its context only
+	p.verifier = &flakyVerifier{fakeVerifier: fakeVerifier{header: header, logs: logs}, failures: 1 << 30}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, p.relayBlock(ctx, 100, logs, blockInfoChan), context.DeadlineExceeded)
+}
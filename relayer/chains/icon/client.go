@@ -18,6 +18,7 @@ import (
 	"time"
 
 	"github.com/icon-project/centralized-relay/relayer/chains/icon/types"
+	"github.com/icon-project/centralized-relay/relayer/signer"
 	"go.uber.org/zap"
 
 	"github.com/gorilla/websocket"
@@ -25,7 +26,6 @@ import (
 	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/codec"
 	"github.com/icon-project/goloop/common/crypto"
-	"github.com/icon-project/goloop/module"
 	"github.com/icon-project/goloop/server/jsonrpc"
 	"github.com/icon-project/goloop/service/transaction"
 	"github.com/pkg/errors"
@@ -73,7 +73,7 @@ type Client struct {
 
 var txSerializeExcludes = map[string]bool{"signature": true}
 
-func (c *Client) SignTransaction(w module.Wallet, p *types.TransactionParam) error {
+func (c *Client) SignTransaction(ctx context.Context, s signer.Signer, p *types.TransactionParam) error {
 	p.Timestamp = types.NewHexInt(time.Now().UnixNano() / int64(time.Microsecond))
 	js, err := json.Marshal(p)
 	if err != nil {
@@ -87,7 +87,7 @@ func (c *Client) SignTransaction(w module.Wallet, p *types.TransactionParam) err
 	bs = append([]byte("icx_sendTransaction."), bs...)
 	txHash := crypto.SHA3Sum256(bs)
 	p.TxHash = types.NewHexBytes(txHash)
-	sig, err := w.Sign(txHash)
+	sig, err := s.Sign(ctx, txHash)
 	if err != nil {
 		return err
 	}
@@ -4,7 +4,10 @@ import (
 	"testing"
 
 	"github.com/icon-project/centralized-relay/relayer/chains/icon/types"
+	"github.com/icon-project/centralized-relay/relayer/events"
+	providerTypes "github.com/icon-project/centralized-relay/relayer/types"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
 )
 
 func TestGetMonitorEventFilters(t *testing.T) {
@@ -26,3 +29,28 @@ func TestGetMonitorEventFilters(t *testing.T) {
 	)
 
 }
+
+func TestEmitMessageEventLog(t *testing.T) {
+	icp := &IconProvider{PCfg: &IconProviderConfig{NID: "0x2.icon"}}
+	el, err := emitMessageEventLog(types.EventLogStr{
+		Addr:    "cx000",
+		Indexed: []string{EmitMessage, "0x13881.mumbai", "0x1f"},
+		Data:    []string{"0x6869"},
+	})
+	assert.NoError(t, err)
+
+	m, ok := icp.parseMessageFromEvent(zap.NewNop(), el, 20)
+	assert.True(t, ok)
+	assert.Equal(t, &providerTypes.Message{
+		Src:           "0x2.icon",
+		Dst:           "0x13881.mumbai",
+		Sn:            31,
+		Data:          []byte("hi"),
+		MessageHeight: 20,
+		EventType:     events.EmitMessage,
+	}, m)
+
+	el, err = emitMessageEventLog(types.EventLogStr{Indexed: []string{"Other(str)"}, Data: []string{"0x"}})
+	assert.NoError(t, err)
+	assert.Nil(t, el)
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 
+	"github.com/icon-project/goloop/common/crypto"
 	"github.com/icon-project/goloop/common/wallet"
 	"github.com/icon-project/goloop/module"
 )
@@ -38,3 +39,38 @@ func getAddrFromKeystore(keystorePath string) (string, error) {
 	return a.Address, nil
 
 }
+
+// NewKeystore encrypts the private key as an icon keystore, a new key is generated when privateKey is nil
+func NewKeystore(privateKey []byte, password string) ([]byte, string, error) {
+	var (
+		pk  *crypto.PrivateKey
+		err error
+	)
+	if privateKey == nil {
+		pk, _ = crypto.GenerateKeyPair()
+	} else if pk, err = crypto.ParsePrivateKey(privateKey); err != nil {
+		return nil, "", err
+	}
+	w, err := wallet.NewFromPrivateKey(pk)
+	if err != nil {
+		return nil, "", err
+	}
+	data, err := wallet.EncryptKeyAsKeyStore(pk, []byte(password))
+	if err != nil {
+		return nil, "", err
+	}
+	return data, w.Address().String(), nil
+}
+
+// DecryptKeystore returns the address and the private key of the keystore
+func DecryptKeystore(data []byte, password string) (string, []byte, error) {
+	pk, err := wallet.DecryptKeyStore(data, []byte(password))
+	if err != nil {
+		return "", nil, err
+	}
+	w, err := wallet.NewFromPrivateKey(pk)
+	if err != nil {
+		return "", nil, err
+	}
+	return w.Address().String(), pk.Bytes(), nil
+}
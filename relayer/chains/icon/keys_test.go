@@ -1,8 +1,11 @@
 package icon
 
 import (
+	"os"
 	"testing"
 
+	"github.com/icon-project/goloop/common/wallet"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -28,3 +31,23 @@ func TestGetAddrFromKeystore(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expectedAddr, addr)
 }
+
+func TestNewKeystore(t *testing.T) {
+	data, err := os.ReadFile("../../../example/wallets/icon/keystore.json")
+	assert.NoError(t, err)
+	addr, privateKey, err := DecryptKeystore(data, testKeyPassword)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedAddr, addr)
+
+	data, addr, err = NewKeystore(privateKey, "new-secret")
+	assert.NoError(t, err)
+	assert.Equal(t, expectedAddr, addr)
+
+	w, err := wallet.NewFromKeyStore(data, []byte("new-secret"))
+	assert.NoError(t, err)
+	assert.Equal(t, expectedAddr, w.Address().String())
+
+	_, generated, err := NewKeystore(nil, "new-secret")
+	assert.NoError(t, err)
+	assert.NotEqual(t, expectedAddr, generated)
+}
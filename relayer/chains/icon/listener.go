@@ -62,6 +62,7 @@ func (icp *IconProvider) Listener(ctx context.Context, lastSavedHeight uint64, i
 	}
 
 	icp.log.Info("Start querying from height", zap.Int64("height", processedheight))
+	go icp.StartHealthCheck(ctx)
 	// subscribe to monitor block
 	ctxMonitorBlock, cancelMonitorBlock := context.WithCancel(ctx)
 	reconnect()
@@ -86,7 +87,7 @@ loop:
 			go func(ctx context.Context, cancel context.CancelFunc) {
 				blockReq.Height = types.NewHexInt(int64(processedheight))
 				icp.log.Debug("try to reconnect from", zap.Int64("height", processedheight))
-				err := icp.client.MonitorBlock(ctx, blockReq, func(conn *websocket.Conn, v *types.BlockNotification) error {
+				err := icp.client().MonitorBlock(ctx, blockReq, func(conn *websocket.Conn, v *types.BlockNotification) error {
 					if !errors.Is(ctx.Err(), context.Canceled) {
 						btpBlockNotifCh <- v
 					}
@@ -230,7 +231,7 @@ func (icp *IconProvider) handleBTPBlockRequest(
 
 	containsEventlogs := len(request.indexes) > 0 && len(request.events) > 0
 	if containsEventlogs {
-		blockHeader, err := icp.client.GetBlockHeaderByHeight(request.height)
+		blockHeader, err := icp.client().GetBlockHeaderByHeight(request.height)
 		if err != nil {
 			request.err = errors.Wrapf(request.err, "getBlockHeader: %v", err)
 			return
@@ -253,7 +254,7 @@ func (icp *IconProvider) handleBTPBlockRequest(
 					Events:    request.events[id][i],
 				}
 
-				proofs, err := icp.client.GetProofForEvents(p)
+				proofs, err := icp.client().GetProofForEvents(p)
 				if err != nil {
 					request.err = errors.Wrapf(err, "GetProofForEvents: %v", err)
 					return
@@ -4,4 +4,5 @@ var (
 	MethodSendMessage = "sendMessage"
 	MethodRecvMessage = "recvMessage"
 	MethodGetReceipts = "getReceipts"
+	MethodConnSn      = "connSn"
 )
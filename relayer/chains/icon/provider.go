@@ -3,21 +3,32 @@ package icon
 import (
 	"context"
 	"fmt"
+	"regexp"
 
+	"github.com/icon-project/centralized-relay/relayer/endpoint"
 	"github.com/icon-project/centralized-relay/relayer/provider"
-	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/centralized-relay/relayer/signer"
+	rlywallet "github.com/icon-project/centralized-relay/relayer/wallet"
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/crypto"
 	"go.uber.org/zap"
 )
 
 type IconProviderConfig struct {
-	ChainName       string `json:"-" yaml:"-"`
-	RPCUrl          string `json:"rpc-url" yaml:"rpc-url"`
-	KeyStore        string `json:"keystore" yaml:"keystore"`
-	Password        string `json:"password" yaml:"password"`
-	StartHeight     uint64 `json:"start-height" yaml:"start-height"` // would be of highest priority
-	ContractAddress string `json:"contract-address" yaml:"contract-address"`
-	NetworkID       uint   `json:"network-id" yaml:"network-id"`
-	NID             string `json:"nid" yaml:"nid"`
+	ChainName       string   `json:"-" yaml:"-"`
+	RPCUrl          string   `json:"rpc-url" yaml:"rpc-url"`
+	RPCUrls         []string `json:"rpc-urls" yaml:"rpc-urls"`
+	KeyStore        string   `json:"keystore" yaml:"keystore"`
+	Password        string   `json:"password" yaml:"password"`
+	StartHeight     uint64   `json:"start-height" yaml:"start-height"` // would be of highest priority
+	ContractAddress string   `json:"contract-address" yaml:"contract-address"`
+	NetworkID       uint     `json:"network-id" yaml:"network-id"`
+	NID             string   `json:"nid" yaml:"nid"`
+	// Signer replaces the keystore with a remote or hsm signer
+	Signer *signer.Config `json:"signer,omitempty" yaml:"signer,omitempty"`
+	// Wallets submit along with the keystore
+	Wallets        []rlywallet.Key    `json:"wallets,omitempty" yaml:"wallets,omitempty"`
+	WalletStrategy rlywallet.Strategy `json:"wallet-strategy,omitempty" yaml:"wallet-strategy,omitempty"`
 }
 
 // NewProvider returns new Icon provider
@@ -28,29 +39,85 @@ func (pp *IconProviderConfig) NewProvider(log *zap.Logger, homepath string, debu
 
 	pp.ChainName = chainName
 
+	var endpoints []*endpoint.Endpoint[*Client]
+	for _, url := range pp.Endpoints() {
+		endpoints = append(endpoints, &endpoint.Endpoint[*Client]{URL: url, Client: NewClient(url, log)})
+	}
+	clients, err := endpoint.NewPool(log, pp.NID, endpoints...)
+	if err != nil {
+		return nil, err
+	}
+
 	return &IconProvider{
-		log:    log.With(zap.String("nid ", pp.NID)),
-		client: NewClient(pp.RPCUrl, log),
-		PCfg:   pp,
+		log:     log.With(zap.String("nid ", pp.NID)),
+		clients: clients,
+		PCfg:    pp,
 	}, nil
 }
 
+func (pp *IconProviderConfig) GetNID() string {
+	return pp.NID
+}
+
+// Endpoints returns rpc-url followed by the additional rpc-urls without duplicates
+func (pp *IconProviderConfig) Endpoints() []string {
+	return provider.Endpoints(pp.RPCUrl, pp.RPCUrls)
+}
+
+var contractAddressPattern = regexp.MustCompile("^cx[0-9a-f]{40}$")
+
+// Validate reports every problem of the config which is found without reading the keystores
 func (pp *IconProviderConfig) Validate() error {
-	if pp.RPCUrl == "" {
-		return fmt.Errorf("icon provider rpc endpoint is empty")
+	var errs provider.ConfigErrors
+	if pp.NID == "" {
+		errs.Add("nid is required")
+	}
+	if pp.NetworkID == 0 {
+		errs.Add("network-id is required")
 	}
 
-	// TODO: validation for keystore
-	// TODO: contractaddress validation
-	// TODO: account should have some balance no balance then use another accoutn
+	errs.AddErr(provider.ValidateEndpoints("rpc-url", pp.Endpoints(), "http", "https"))
 
-	return nil
+	if !contractAddressPattern.MatchString(pp.ContractAddress) {
+		errs.Add("contract-address %q is not a valid icon contract address", pp.ContractAddress)
+	}
+
+	errs.AddErr(rlywallet.ValidateKeys(pp.Keys(), pp.WalletStrategy))
+	return errs.Err()
+}
+
+// ValidateKeystores checks that every keystore opens with its password, each of them is decrypted
+func (pp *IconProviderConfig) ValidateKeystores() error {
+	return rlywallet.ValidateKeystores(pp.Keys(), decryptKeystore)
+}
+
+// Keys returns the key of the config followed by the additional wallets
+func (pp *IconProviderConfig) Keys() []rlywallet.Key {
+	return append([]rlywallet.Key{{Keystore: pp.KeyStore, Password: pp.Password, Signer: pp.Signer}}, pp.Wallets...)
 }
 
 type IconProvider struct {
-	log    *zap.Logger
-	PCfg   *IconProviderConfig
-	client *Client
+	log     *zap.Logger
+	PCfg    *IconProviderConfig
+	clients *endpoint.Pool[*Client]
+	// wallets are loaded by Init, the primary one is the keystore or signer of the config
+	wallets *rlywallet.Pool
+}
+
+// client returns the client of the healthiest rpc endpoint
+func (ip *IconProvider) client() *Client {
+	return ip.clients.Best()
+}
+
+// StartHealthCheck keeps the rpc endpoint health up to date until ctx is done
+func (ip *IconProvider) StartHealthCheck(ctx context.Context) {
+	ip.clients.StartHealthCheck(ctx, endpoint.DefaultHealthCheckInterval, func(ctx context.Context, cl *Client) (uint64, error) {
+		block, err := cl.GetLastBlock()
+		if err != nil {
+			return 0, err
+		}
+		return uint64(block.Height), nil
+	})
 }
 
 func (ip *IconProvider) NID() string {
@@ -58,9 +125,29 @@ func (ip *IconProvider) NID() string {
 }
 
 func (ip *IconProvider) Init(ctx context.Context) error {
+	pool, err := rlywallet.Load(ctx, ip.PCfg.Keys(), ip.PCfg.WalletStrategy, decryptKeystore, signerAddress)
+	if err != nil {
+		return fmt.Errorf("failed to load icon %w", err)
+	}
+	ip.wallets = pool
 	return nil
 }
 
+// decryptKeystore returns the private key of an icon keystore
+func decryptKeystore(data []byte, password string) ([]byte, error) {
+	_, privateKey, err := DecryptKeystore(data, password)
+	return privateKey, err
+}
+
+func (ip *IconProvider) Close() error {
+	return ip.wallets.Close()
+}
+
+// Wallets returns the wallet pool loaded by Init
+func (ip *IconProvider) Wallets() *rlywallet.Pool {
+	return ip.wallets
+}
+
 func (p *IconProvider) Type() string {
 	return "icon"
 }
@@ -73,14 +160,29 @@ func (p *IconProvider) ChainName() string {
 	return p.PCfg.ChainName
 }
 
-func (cp *IconProvider) Wallet() (module.Wallet, error) {
-	return cp.RestoreIconKeyStore()
+// Signer returns the signer of the primary wallet loaded by Init
+func (cp *IconProvider) Signer() (signer.Signer, error) {
+	if cp.wallets == nil {
+		return nil, fmt.Errorf("icon signer is not loaded")
+	}
+	return cp.wallets.Primary().Signer, nil
 }
-
 func (cp *IconProvider) GetWalletAddress() (address string, err error) {
+	if cp.wallets != nil {
+		return cp.wallets.Primary().Address, nil
+	}
 	return getAddrFromKeystore(cp.PCfg.KeyStore)
 }
 
+// signerAddress returns the icon address of the public key of a signer
+func signerAddress(publicKey []byte) (string, error) {
+	key, err := crypto.ParsePublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+	return common.NewAccountAddressFromPublicKey(key).String(), nil
+}
+
 func (icp *IconProvider) FinalityBlock(ctx context.Context) uint64 {
 	return 0
 }
@@ -80,3 +80,28 @@ func TestGenerateMessage(t *testing.T) {
 	// {"Sn":45,"Src":"0x2.icon","Dst":"0x13881.mumbai","EventType":"emitMessage","MsgHeight":31969244}
 
 }
+
+func TestValidate(t *testing.T) {
+	cfg := IconProviderConfig{
+		NID:             "0x2.icon",
+		NetworkID:       2,
+		KeyStore:        "../../../example/wallets/icon/keystore.json",
+		RPCUrl:          "https://lisbon.net.solidwallet.io/api/v3/",
+		Password:        testKeyPassword,
+		ContractAddress: "cxb2b31a5252bfcc9be29441c626b8b918d578a58b",
+	}
+	assert.NoError(t, cfg.Validate())
+
+	invalid := IconProviderConfig{
+		KeyStore:        "missing.json",
+		RPCUrl:          "lisbon.net.solidwallet.io",
+		ContractAddress: "hxb2b31a5252bfcc9be29441c626b8b918d578a58b",
+	}
+	err := invalid.Validate()
+	assert.Error(t, err)
+	for _, problem := range []string{"nid", "network-id", "rpc-url", "contract-address"} {
+		assert.ErrorContains(t, err, problem)
+	}
+	assert.NoError(t, cfg.ValidateKeystores())
+	assert.ErrorContains(t, invalid.ValidateKeystores(), "keystore")
+}
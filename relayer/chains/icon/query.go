@@ -8,7 +8,8 @@ import (
 	"strings"
 
 	"github.com/icon-project/centralized-relay/relayer/chains/icon/types"
-	"github.com/icon-project/centralized-relay/relayer/events"
+	"github.com/icon-project/centralized-relay/relayer/endpoint"
+	"github.com/icon-project/centralized-relay/relayer/provider"
 	providerTypes "github.com/icon-project/centralized-relay/relayer/types"
 	"go.uber.org/zap"
 )
@@ -44,7 +45,9 @@ func (ip *IconProvider) prepareCallParams(methodName string, param map[string]in
 }
 
 func (ip *IconProvider) QueryLatestHeight(ctx context.Context) (uint64, error) {
-	block, err := ip.client.GetLastBlock()
+	block, err := endpoint.Do(ip.clients, func(cl *Client) (*types.Block, error) {
+		return cl.GetLastBlock()
+	})
 	if err != nil {
 		return 0, err
 	}
@@ -68,7 +71,7 @@ func (ip *IconProvider) MessageReceived(ctx context.Context, messageKey provider
 	})
 
 	var status types.HexInt
-	err := ip.client.Call(callParam, &status)
+	err := ip.client().Call(callParam, &status)
 	if err != nil {
 		return false, fmt.Errorf("MessageReceived: %v", err)
 	}
@@ -80,15 +83,37 @@ func (ip *IconProvider) MessageReceived(ctx context.Context, messageKey provider
 	return false, nil
 }
 
+var _ provider.SnQuerier = (*IconProvider)(nil)
+
+// QueryConnSn returns the sn of the connection contract at height
+func (ip *IconProvider) QueryConnSn(ctx context.Context, height uint64) (uint64, error) {
+	var options []CallParamOption
+	if height > 0 {
+		// the state of a block is the result of the transactions of the block before it
+		options = append(options, callParamsWithHeight(types.NewHexInt(int64(height+1))))
+	}
+	callParam := ip.prepareCallParams(MethodConnSn, map[string]interface{}{}, options...)
+
+	var sn types.HexInt
+	if err := ip.client().Call(callParam, &sn); err != nil {
+		return 0, fmt.Errorf("QueryConnSn: %v", err)
+	}
+	value, err := sn.Value()
+	if err != nil {
+		return 0, fmt.Errorf("QueryConnSn: %v", err)
+	}
+	return uint64(value), nil
+}
+
 func (ip *IconProvider) QueryBalance(ctx context.Context, addr string) (*providerTypes.Coin, error) {
 	param := types.AddressParam{
 		Address: types.Address(addr),
 	}
-	balance, err := ip.client.GetBalance(&param)
+	balance, err := ip.client().GetBalance(&param)
 	if err != nil {
 		return nil, err
 	}
-	coin := providerTypes.NewCoin("ICX", balance.Uint64())
+	coin := providerTypes.NewCoin("ICX", balance, 18)
 	return &coin, nil
 }
 
@@ -98,67 +123,128 @@ func (ip *IconProvider) GenerateMessage(ctx context.Context, key *providerTypes.
 		return nil, errors.New("GenerateMessage: message key cannot be nil")
 	}
 
-	var eventName = ""
-	switch key.EventType {
-	case events.EmitMessage:
-		eventName = EmitMessage
+	messages, err := ip.blockMessages(key.MsgHeight)
+	if err != nil {
+		return nil, fmt.Errorf("GenerateMessage: %v", err)
+	}
+	// a block can emit several messages
+	for _, m := range messages {
+		if m.Sn == key.Sn && m.EventType == key.EventType {
+			return m, nil
+		}
 	}
 
-	block, err := ip.client.GetBlockByHeight(&types.BlockHeightParam{
-		Height: types.NewHexInt(int64(key.MsgHeight)),
+	return nil, fmt.Errorf(
+		"error generating message: %v", key)
+}
+
+// Rescan parses the messages of the blocks from..to which emitted events, the listener and its checkpoint are not affected
+func (ip *IconProvider) Rescan(ctx context.Context, from, to uint64) ([]providerTypes.BlockInfo, error) {
+	blocks := make([]providerTypes.BlockInfo, 0)
+	for height := from; height <= to; height++ {
+		if err := ctx.Err(); err != nil {
+			return blocks, err
+		}
+		messages, err := ip.blockMessages(height)
+		if err != nil {
+			return blocks, fmt.Errorf("height %d: %w", height, err)
+		}
+		if len(messages) > 0 {
+			blocks = append(blocks, providerTypes.BlockInfo{Height: height, Messages: messages})
+		}
+	}
+	return blocks, nil
+}
+
+// blockMessages parses the messages emitted by the connection in the transactions of the block at height
+func (ip *IconProvider) blockMessages(height uint64) ([]*providerTypes.Message, error) {
+	block, err := ip.client().GetBlockByHeight(&types.BlockHeightParam{
+		Height: types.NewHexInt(int64(height)),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("GenerateMessage:GetBlockByHeight %v", err)
+		return nil, fmt.Errorf("GetBlockByHeight %v", err)
 	}
 
+	var eventLogs []*types.EventLog
 	for _, res := range block.NormalTransactions {
-		txResult, err := ip.client.GetTransactionResult(&types.TransactionHashParam{
+		txResult, err := ip.client().GetTransactionResult(&types.TransactionHashParam{
 			Hash: res.TxHash,
 		})
 		if err != nil {
-			return nil, fmt.Errorf("GenerateMessage:GetTransactionResult %v", err)
+			return nil, fmt.Errorf("GetTransactionResult %v", err)
 		}
+		eventLogs = append(eventLogs, ip.connectionEventLogs(txResult)...)
+	}
+	return ip.parseMessagesFromEventlogs(ip.log, eventLogs, height), nil
+}
+
+var (
+	_ provider.Rescanner       = (*IconProvider)(nil)
+	_ provider.TxMessageFinder = (*IconProvider)(nil)
+)
 
-		for _, el := range txResult.EventLogs {
-			if el.Addr != types.Address(ip.PCfg.ContractAddress) &&
-				len(el.Indexed) != 3 && len(el.Data) != 1 &&
-				el.Indexed[0] != eventName {
-				continue
-			}
-
-			dst := el.Indexed[1]
-			sn, ok := big.NewInt(0).SetString(el.Indexed[2], 0)
-			if !ok {
-				ip.log.Error("GenerateMessage: error decoding int value ")
-				continue
-			}
-
-			data := types.HexBytes(el.Data[0])
-			dataValue, err := data.Value()
-			if err != nil {
-				ip.log.Error("GenerateMessage: error decoding data ", zap.Error(err))
-				continue
-			}
-
-			return &providerTypes.Message{
-				MessageHeight: key.MsgHeight,
-				EventType:     key.EventType,
-				Dst:           dst,
-				Src:           key.Src,
-				Data:          dataValue,
-				Sn:            sn.Uint64(),
-			}, nil
+// TxMessages parses the messages emitted by the connection in the transaction txHash
+func (ip *IconProvider) TxMessages(ctx context.Context, txHash string) ([]*providerTypes.Message, error) {
+	txResult, err := ip.client().GetTransactionResult(&types.TransactionHashParam{
+		Hash: types.HexBytes(txHash),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("TxMessages: GetTransactionResult: %v", err)
+	}
+	if status, err := txResult.Status.Int(); err != nil || status != 1 {
+		return nil, fmt.Errorf("transaction %s failed", txHash)
+	}
+	height, err := txResult.BlockHeight.Value()
+	if err != nil {
+		return nil, fmt.Errorf("TxMessages: block height: %v", err)
+	}
+	return ip.parseMessagesFromEventlogs(ip.log, ip.connectionEventLogs(txResult), uint64(height)), nil
+}
+
+// connectionEventLogs returns the emit message events of the connection in the transaction result
+func (ip *IconProvider) connectionEventLogs(txResult *types.TransactionResult) []*types.EventLog {
+	var eventLogs []*types.EventLog
+	for _, el := range txResult.EventLogs {
+		if el.Addr != types.Address(ip.PCfg.ContractAddress) {
+			continue
+		}
+		eventLog, err := emitMessageEventLog(el)
+		if err != nil {
+			ip.log.Error("invalid event", zap.String("tx-hash", string(txResult.TxHash)), zap.Error(err))
+			continue
+		}
+		if eventLog != nil {
+			eventLogs = append(eventLogs, eventLog)
 		}
 	}
+	return eventLogs
+}
 
-	return nil, fmt.Errorf(
-		"error generating message: %v", key)
+// emitMessageEventLog converts an emit message event of a transaction result into the
+// event log of the block notifications, it returns nil for the other events
+func emitMessageEventLog(el types.EventLogStr) (*types.EventLog, error) {
+	if len(el.Indexed) != 3 || len(el.Data) != 1 || el.Indexed[0] != EmitMessage {
+		return nil, nil
+	}
+	sn, ok := big.NewInt(0).SetString(el.Indexed[2], 0)
+	if !ok {
+		return nil, fmt.Errorf("invalid sn %q", el.Indexed[2])
+	}
+	data, err := types.HexBytes(el.Data[0]).Value()
+	if err != nil {
+		return nil, fmt.Errorf("invalid data: %w", err)
+	}
+	return &types.EventLog{
+		Addr:    el.Addr,
+		Indexed: [][]byte{[]byte(el.Indexed[0]), []byte(el.Indexed[1]), sn.Bytes()},
+		Data:    [][]byte{data},
+	}, nil
 }
 
 // QueryTransactionReceipt ->
 // TxHash should be in hex string
 func (icp *IconProvider) QueryTransactionReceipt(ctx context.Context, txHash string) (*providerTypes.Receipt, error) {
-	res, err := icp.client.GetTransactionResult(&types.TransactionHashParam{
+	res, err := icp.client().GetTransactionResult(&types.TransactionHashParam{
 		Hash: types.HexBytes(txHash),
 	})
 	if err != nil {
@@ -8,6 +8,7 @@ import (
 	"github.com/icon-project/centralized-relay/relayer/chains/icon/types"
 	"github.com/icon-project/centralized-relay/relayer/events"
 	providerTypes "github.com/icon-project/centralized-relay/relayer/types"
+	rlywallet "github.com/icon-project/centralized-relay/relayer/wallet"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
@@ -22,13 +23,24 @@ func (icp *IconProvider) Route(ctx context.Context, message *providerTypes.Messa
 		return err
 	}
 
+	if icp.wallets == nil {
+		return fmt.Errorf("icon signer is not loaded")
+	}
+
 	messageKey := message.MessageKey()
-	txhash, err := icp.SendTransaction(ctx, iconMessage)
+	w := icp.wallets.Acquire()
+	txhash, err := icp.SendTransaction(ctx, w, iconMessage)
 	if err != nil {
+		w.Release(err)
 		return errors.Wrapf(err, "error occured while sending transaction")
 	}
 
-	go icp.WaitForTxResult(ctx, txhash, messageKey, iconMessage.Method, callback)
+	go icp.WaitForTxResult(ctx, txhash, messageKey, iconMessage.Method, func(key providerTypes.MessageKey, res providerTypes.TxResponse, err error) {
+		w.Release(err)
+		if callback != nil {
+			callback(key, res, err)
+		}
+	})
 
 	return nil
 }
@@ -47,18 +59,17 @@ func (icp *IconProvider) MakeIconMessage(message *providerTypes.Message) (IconMe
 	return IconMessage{}, fmt.Errorf("can't generate message for unknown event type: %s ", message.EventType)
 }
 
+// SendTransaction signs and sends the message from the wallet
 func (icp *IconProvider) SendTransaction(
 	ctx context.Context,
+	w *rlywallet.Wallet,
 	msg IconMessage,
 ) ([]byte, error) {
-	wallet, err := icp.Wallet()
-	if err != nil {
-		return nil, err
-	}
+	s, from := w.Signer, w.Address
 
 	txParamEst := &types.TransactionParamForEstimate{
 		Version:     types.NewHexInt(JsonrpcApiVersion),
-		FromAddress: types.Address(wallet.Address().String()),
+		FromAddress: types.Address(from),
 		ToAddress:   types.Address(icp.PCfg.ContractAddress),
 		NetworkID:   types.NewHexInt(int64(icp.PCfg.NetworkID)),
 		DataType:    "call",
@@ -68,7 +79,7 @@ func (icp *IconProvider) SendTransaction(
 		},
 	}
 
-	step, err := icp.client.EstimateStep(txParamEst)
+	step, err := icp.client().EstimateStep(txParamEst)
 	if err != nil {
 		return nil, fmt.Errorf("failed estimating step: %w", err)
 	}
@@ -81,7 +92,7 @@ func (icp *IconProvider) SendTransaction(
 
 	txParam := &types.TransactionParam{
 		Version:     types.NewHexInt(JsonrpcApiVersion),
-		FromAddress: types.Address(wallet.Address().String()),
+		FromAddress: types.Address(from),
 		ToAddress:   types.Address(icp.PCfg.ContractAddress),
 		NetworkID:   types.NewHexInt(int64(icp.PCfg.NetworkID)),
 		StepLimit:   stepLimit,
@@ -92,11 +103,11 @@ func (icp *IconProvider) SendTransaction(
 		},
 	}
 
-	if err := icp.client.SignTransaction(wallet, txParam); err != nil {
+	if err := icp.client().SignTransaction(ctx, s, txParam); err != nil {
 		return nil, err
 	}
 
-	_, err = icp.client.SendTransaction(txParam)
+	_, err = icp.client().SendTransaction(txParam)
 	if err != nil {
 		return nil, err
 	}
@@ -120,7 +131,7 @@ func (icp *IconProvider) WaitForTxResult(
 	res := providerTypes.TxResponse{}
 	res.TxHash = string(txHash)
 
-	_, txRes, err := icp.client.WaitForResults(ctx, &types.TransactionHashParam{Hash: txhash})
+	_, txRes, err := icp.client().WaitForResults(ctx, &types.TransactionHashParam{Hash: txhash})
 	if err != nil {
 		icp.log.Error("Failed to get txn result", zap.String("txHash", string(txhash)), zap.String("method", method), zap.Error(err))
 		callback(messageKey, res, err)
@@ -130,6 +141,7 @@ func (icp *IconProvider) WaitForTxResult(
 	height, err := txRes.BlockHeight.Value()
 	if err != nil {
 		callback(messageKey, res, err)
+		return
 	}
 	// assign tx successful height
 	res.Height = height
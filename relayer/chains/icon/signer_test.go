@@ -0,0 +1,66 @@
+package icon
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/icon-project/centralized-relay/relayer/signer"
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/common/wallet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const testKeyStoreFile = "../../../example/wallets/icon/keystore.json"
+
+func TestSigner(t *testing.T) {
+	data, err := os.ReadFile(testKeyStoreFile)
+	require.NoError(t, err)
+	w, err := wallet.NewFromKeyStore(data, []byte(testKeyPassword))
+	require.NoError(t, err)
+
+	p := &IconProvider{PCfg: &IconProviderConfig{KeyStore: testKeyStoreFile, Password: testKeyPassword}}
+	_, err = p.Signer()
+	assert.Error(t, err)
+	require.NoError(t, p.Init(context.Background()))
+	s, err := p.Signer()
+	require.NoError(t, err)
+	addr, err := signerAddress(s.PublicKey())
+	require.NoError(t, err)
+	assert.Equal(t, expectedAddr, addr)
+
+	// the signatures of the keystore signer are the ones of the goloop wallet
+	digest := crypto.SHA3Sum256([]byte("icx_sendTransaction.test"))
+	sig, err := s.Sign(context.Background(), digest)
+	require.NoError(t, err)
+	expected, err := w.Sign(digest)
+	require.NoError(t, err)
+	assert.Equal(t, expected, sig)
+
+	// the keystore is decrypted once, a wrong password fails on init
+	invalid := &IconProvider{PCfg: &IconProviderConfig{KeyStore: testKeyStoreFile, Password: "wrong"}}
+	assert.Error(t, invalid.Init(context.Background()))
+
+	srv := httptest.NewServer(signer.NewServer(zap.NewNop(), map[string]signer.Signer{"relayer": s}, ""))
+	defer srv.Close()
+	remote := &IconProvider{PCfg: &IconProviderConfig{Signer: &signer.Config{
+		Type:   signer.TypeRemote,
+		Remote: &signer.RemoteConfig{URL: srv.URL, KeyID: "relayer"},
+	}}}
+	require.NoError(t, remote.Init(context.Background()))
+	addr, err = remote.GetWalletAddress()
+	require.NoError(t, err)
+	assert.Equal(t, expectedAddr, addr)
+	rs, err := remote.Signer()
+	require.NoError(t, err)
+	sig, err = rs.Sign(context.Background(), digest)
+	require.NoError(t, err)
+	assert.Equal(t, expected, sig)
+
+	require.NoError(t, p.Close())
+	_, err = s.Sign(context.Background(), digest)
+	assert.ErrorIs(t, err, signer.ErrClosed)
+}
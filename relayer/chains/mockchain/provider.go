@@ -2,6 +2,7 @@ package mockchain
 
 import (
 	"context"
+	"math/big"
 	"time"
 
 	"github.com/icon-project/centralized-relay/relayer/provider"
@@ -16,7 +17,9 @@ type MockProviderConfig struct {
 	SendMessages    map[types.MessageKey]*types.Message
 	ReceiveMessages map[types.MessageKey]*types.Message
 	StartHeight     uint64
-	chainName       string
+	// Balance of the relayer wallet, nil when not tracked
+	Balance   *big.Int
+	chainName string
 }
 
 // NewProvider should provide a new Mock provider
@@ -37,6 +40,10 @@ func (pp *MockProviderConfig) Validate() error {
 	return nil
 }
 
+func (pp *MockProviderConfig) GetNID() string {
+	return pp.NId
+}
+
 type MockProvider struct {
 	log    *zap.Logger
 	PCfg   *MockProviderConfig
@@ -140,7 +147,15 @@ func (icp *MockProvider) ShouldSendMessage(ctx context.Context, messageKey types
 }
 
 func (icp *MockProvider) QueryBalance(ctx context.Context, addr string) (*types.Coin, error) {
-	return nil, nil
+	if icp.PCfg.Balance == nil {
+		return nil, nil
+	}
+	coin := types.NewCoin("mock", icp.PCfg.Balance, 0)
+	return &coin, nil
+}
+
+func (icp *MockProvider) GetWalletAddress() (string, error) {
+	return "mock-wallet", nil
 }
 
 func (icp *MockProvider) QueryTransactionReceipt(ctx context.Context, txHash string) (*types.Receipt, error) {
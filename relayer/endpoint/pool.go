@@ -0,0 +1,273 @@
+package endpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/metrics"
+	"go.uber.org/zap"
+)
+
+const (
+	DefaultHealthCheckInterval = 15 * time.Second
+
+	// ewmaWeight is the weight of the latest observation in the moving averages
+	ewmaWeight = 0.2
+	// maxHeightLag is the number of blocks an endpoint may be behind the best endpoint and still be healthy
+	maxHeightLag = 5
+	// maxErrorRate above which an endpoint is unhealthy
+	maxErrorRate = 0.5
+)
+
+var ErrNoEndpoint = errors.New("no rpc endpoint available")
+
+// Endpoint is a client of a single rpc url along with its health
+type Endpoint[T any] struct {
+	URL    string
+	Client T
+
+	latency   float64 // seconds
+	errorRate float64
+	height    uint64
+	requests  uint64
+	failures  uint64
+	lastError error
+}
+
+// Health is a snapshot of the health of an endpoint
+type Health struct {
+	URL       string
+	Latency   time.Duration
+	ErrorRate float64
+	Height    uint64
+	Requests  uint64
+	Failures  uint64
+	Healthy   bool
+	LastError string
+}
+
+// Pool selects the healthiest endpoint by latency, error rate and head height
+type Pool[T any] struct {
+	log       *zap.Logger
+	nid       string
+	endpoints []*Endpoint[T]
+	mu        sync.RWMutex
+}
+
+func NewPool[T any](log *zap.Logger, nid string, endpoints ...*Endpoint[T]) (*Pool[T], error) {
+	if len(endpoints) == 0 {
+		return nil, ErrNoEndpoint
+	}
+	return &Pool[T]{
+		log:       log,
+		nid:       nid,
+		endpoints: endpoints,
+	}, nil
+}
+
+// Best returns the client of the healthiest endpoint
+func (p *Pool[T]) Best() T {
+	return p.Ordered()[0].Client
+}
+
+// Ordered returns the endpoints from the healthiest to the least healthy
+func (p *Pool[T]) Ordered() []*Endpoint[T] {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	best := p.bestHeight()
+	ordered := make([]*Endpoint[T], len(p.endpoints))
+	copy(ordered, p.endpoints)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].score(best) < ordered[j].score(best)
+	})
+	return ordered
+}
+
+// Observe records the outcome of a request made to the endpoint
+func (p *Pool[T]) Observe(ep *Endpoint[T], latency time.Duration, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	failed := 0.0
+	ep.requests++
+	if err != nil {
+		failed = 1
+		ep.failures++
+		ep.lastError = err
+	}
+	if ep.requests == 1 {
+		ep.latency, ep.errorRate = latency.Seconds(), failed
+	} else {
+		ep.latency = ewma(ep.latency, latency.Seconds())
+		ep.errorRate = ewma(ep.errorRate, failed)
+	}
+	p.report(ep)
+}
+
+// SetHeight records the head height reported by the endpoint
+func (p *Pool[T]) SetHeight(ep *Endpoint[T], height uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ep.height = height
+	p.report(ep)
+}
+
+// Health returns the health of all the endpoints, healthiest first
+func (p *Pool[T]) Health() []Health {
+	ordered := p.Ordered()
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	best := p.bestHeight()
+	health := make([]Health, 0, len(ordered))
+	for _, ep := range ordered {
+		h := Health{
+			URL:       ep.URL,
+			Latency:   time.Duration(ep.latency * float64(time.Second)),
+			ErrorRate: ep.errorRate,
+			Height:    ep.height,
+			Requests:  ep.requests,
+			Failures:  ep.failures,
+			Healthy:   ep.healthy(best),
+		}
+		if ep.lastError != nil {
+			h.LastError = ep.lastError.Error()
+		}
+		health = append(health, h)
+	}
+	return health
+}
+
+// Do calls fn with the endpoints from the healthiest one until a call succeeds
+func Do[T any, R any](p *Pool[T], fn func(T) (R, error)) (R, error) {
+	return DoWith(p, nil, fn)
+}
+
+// DoWith calls fn with the endpoints from the healthiest one until a call succeeds or fails with
+// an error failover does not accept. Such an error is answered the same by every endpoint, the
+// endpoint is not blamed for it. A nil failover accepts every error
+func DoWith[T any, R any](p *Pool[T], failover func(error) bool, fn func(T) (R, error)) (R, error) {
+	var (
+		result R
+		errs   []error
+	)
+	for _, ep := range p.Ordered() {
+		start := time.Now()
+		res, err := fn(ep.Client)
+		if err != nil && failover != nil && !failover(err) {
+			p.Observe(ep, time.Since(start), nil)
+			return result, err
+		}
+		p.Observe(ep, time.Since(start), err)
+		if err == nil {
+			return res, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", ep.URL, err))
+		if len(p.endpoints) > 1 {
+			p.log.Debug("rpc request failed, trying next endpoint", zap.String("url", ep.URL), zap.Error(err))
+		}
+	}
+	if len(errs) == 1 {
+		return result, errors.Unwrap(errs[0])
+	}
+	return result, errors.Join(errs...)
+}
+
+// IsTransportError tells whether err comes from reaching the endpoint rather than from its answer,
+// a request failing that way may succeed on another endpoint
+func IsTransportError(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET)
+}
+
+// StartHealthCheck polls the head height of every endpoint until ctx is done
+func (p *Pool[T]) StartHealthCheck(ctx context.Context, interval time.Duration, latestHeight func(ctx context.Context, client T) (uint64, error)) {
+	if interval == 0 {
+		interval = DefaultHealthCheckInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		p.checkHealth(ctx, latestHeight)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *Pool[T]) checkHealth(ctx context.Context, latestHeight func(ctx context.Context, client T) (uint64, error)) {
+	for _, ep := range p.endpoints {
+		start := time.Now()
+		height, err := latestHeight(ctx, ep.Client)
+		p.Observe(ep, time.Since(start), err)
+		if err == nil {
+			p.SetHeight(ep, height)
+		}
+	}
+	if len(p.endpoints) < 2 {
+		return
+	}
+	for _, h := range p.Health() {
+		p.log.Debug("rpc endpoint health",
+			zap.String("url", h.URL),
+			zap.Bool("healthy", h.Healthy),
+			zap.Duration("latency", h.Latency),
+			zap.Float64("error-rate", h.ErrorRate),
+			zap.Uint64("height", h.Height),
+		)
+	}
+}
+
+func (p *Pool[T]) bestHeight() uint64 {
+	var best uint64
+	for _, ep := range p.endpoints {
+		if ep.height > best {
+			best = ep.height
+		}
+	}
+	return best
+}
+
+func (p *Pool[T]) report(ep *Endpoint[T]) {
+	healthy := 0.0
+	if ep.healthy(p.bestHeight()) {
+		healthy = 1
+	}
+	metrics.RPCEndpointLatency.WithLabelValues(p.nid, ep.URL).Set(ep.latency)
+	metrics.RPCEndpointErrorRate.WithLabelValues(p.nid, ep.URL).Set(ep.errorRate)
+	metrics.RPCEndpointHeight.WithLabelValues(p.nid, ep.URL).Set(float64(ep.height))
+	metrics.RPCEndpointHealthy.WithLabelValues(p.nid, ep.URL).Set(healthy)
+}
+
+func (ep *Endpoint[T]) healthy(bestHeight uint64) bool {
+	return ep.errorRate <= maxErrorRate && ep.height+maxHeightLag >= bestHeight
+}
+
+// score is lower for healthier endpoints, unhealthy endpoints always rank last
+func (ep *Endpoint[T]) score(bestHeight uint64) float64 {
+	score := ep.latency * (1 + 10*ep.errorRate)
+	if !ep.healthy(bestHeight) {
+		score += 1000
+	}
+	return score
+}
+
+func ewma(avg, value float64) float64 {
+	return (1-ewmaWeight)*avg + ewmaWeight*value
+}
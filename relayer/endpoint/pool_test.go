@@ -0,0 +1,125 @@
+package endpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeClient struct {
+	name   string
+	height uint64
+	err    error
+	calls  int
+}
+
+func newTestPool(t *testing.T, clients ...*fakeClient) *Pool[*fakeClient] {
+	var endpoints []*Endpoint[*fakeClient]
+	for _, c := range clients {
+		endpoints = append(endpoints, &Endpoint[*fakeClient]{URL: "http://" + c.name, Client: c})
+	}
+	pool, err := NewPool(zap.NewNop(), "test.nid", endpoints...)
+	require.NoError(t, err)
+	return pool
+}
+
+func call(c *fakeClient) (string, error) {
+	c.calls++
+	return c.name, c.err
+}
+
+func TestNewPoolWithoutEndpoint(t *testing.T) {
+	_, err := NewPool[*fakeClient](zap.NewNop(), "test.nid")
+	assert.ErrorIs(t, err, ErrNoEndpoint)
+}
+
+func TestDoFailover(t *testing.T) {
+	t.Parallel()
+	a := &fakeClient{name: "a", err: errors.New("connection refused")}
+	b := &fakeClient{name: "b"}
+	pool := newTestPool(t, a, b)
+
+	name, err := Do(pool, call)
+	require.NoError(t, err)
+	assert.Equal(t, "b", name)
+
+	// the failing endpoint is ranked after the healthy one
+	assert.Equal(t, "b", pool.Best().name)
+	name, err = Do(pool, call)
+	require.NoError(t, err)
+	assert.Equal(t, "b", name)
+	assert.Equal(t, 1, a.calls)
+}
+
+func TestDoAllFailed(t *testing.T) {
+	t.Parallel()
+	a := &fakeClient{name: "a", err: errors.New("timeout")}
+	b := &fakeClient{name: "b", err: errors.New("bad gateway")}
+	pool := newTestPool(t, a, b)
+
+	_, err := Do(pool, call)
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "timeout")
+	assert.ErrorContains(t, err, "bad gateway")
+}
+
+func TestDoWithFailover(t *testing.T) {
+	t.Parallel()
+	a := &fakeClient{name: "a", err: errors.New("execution reverted")}
+	b := &fakeClient{name: "b"}
+	pool := newTestPool(t, a, b)
+
+	// an answer of the endpoint is not tried on the next one
+	_, err := DoWith(pool, IsTransportError, call)
+	assert.ErrorContains(t, err, "execution reverted")
+	assert.Equal(t, 0, b.calls)
+	for _, h := range pool.Health() {
+		assert.Zero(t, h.Failures)
+	}
+
+	a.err = fmt.Errorf("post: %w", context.DeadlineExceeded)
+	name, err := DoWith(pool, IsTransportError, call)
+	require.NoError(t, err)
+	assert.Equal(t, "b", name)
+}
+
+func TestOrderedByLatencyAndHeight(t *testing.T) {
+	t.Parallel()
+	a := &fakeClient{name: "a"}
+	b := &fakeClient{name: "b"}
+	pool := newTestPool(t, a, b)
+	epA, epB := pool.endpoints[0], pool.endpoints[1]
+
+	pool.Observe(epA, 200*time.Millisecond, nil)
+	pool.Observe(epB, 50*time.Millisecond, nil)
+	assert.Equal(t, "b", pool.Best().name)
+
+	// the faster endpoint lags behind the head and becomes unhealthy
+	pool.SetHeight(epA, 100)
+	pool.SetHeight(epB, 100-maxHeightLag-1)
+	assert.Equal(t, "a", pool.Best().name)
+
+	health := pool.Health()
+	require.Len(t, health, 2)
+	assert.True(t, health[0].Healthy)
+	assert.False(t, health[1].Healthy)
+}
+
+func TestHealthCheck(t *testing.T) {
+	t.Parallel()
+	a := &fakeClient{name: "a", height: 10}
+	b := &fakeClient{name: "b", height: 20}
+	pool := newTestPool(t, a, b)
+
+	pool.checkHealth(context.Background(), func(_ context.Context, c *fakeClient) (uint64, error) {
+		return c.height, c.err
+	})
+	assert.Equal(t, "b", pool.Best().name)
+	assert.Equal(t, uint64(20), pool.Health()[0].Height)
+}
@@ -0,0 +1,315 @@
+package relayer
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/icon-project/centralized-relay/relayer/store"
+	"github.com/icon-project/centralized-relay/relayer/types"
+)
+
+// record kinds of the export format
+const (
+	RecordHeader   = "header"
+	RecordMessage  = "message"
+	RecordBlock    = "block"
+	RecordFinality = "finality"
+	// RecordQuarantined is a message which failed verification
+	RecordQuarantined = "quarantined"
+	// RecordUnroutable is a message parked until its destination is relayed
+	RecordUnroutable = "unroutable"
+	// RecordPause is the pause of a chain or a route
+	RecordPause = "pause"
+	// RecordAudit is the last audited sn of a chain as height and the audits it did not move
+	RecordAudit = "audit"
+)
+
+// ConflictPolicy decides what happens when an imported record already exists in the store
+type ConflictPolicy string
+
+const (
+	ConflictSkip      ConflictPolicy = "skip"
+	ConflictOverwrite ConflictPolicy = "overwrite"
+	ConflictFail      ConflictPolicy = "fail"
+)
+
+func (p ConflictPolicy) Validate() error {
+	switch p {
+	case ConflictSkip, ConflictOverwrite, ConflictFail:
+		return nil
+	}
+	return fmt.Errorf("invalid conflict policy %q: must be one of skip, overwrite or fail", p)
+}
+
+// ExportRecord is a single line of the jsonl export format
+type ExportRecord struct {
+	Kind          string                   `json:"kind"`
+	SchemaVersion uint64                   `json:"schemaVersion,omitempty"`
+	Chain         string                   `json:"chain,omitempty"`
+	Height        uint64                   `json:"height,omitempty"`
+	Message       *types.RouteMessage      `json:"message,omitempty"`
+	TxObject      *types.TransactionObject `json:"txObject,omitempty"`
+	Pause         *types.Pause             `json:"pause,omitempty"`
+	Attempts      uint64                   `json:"attempts,omitempty"`
+}
+
+// ExportStats counts the records processed by an export or import
+type ExportStats struct {
+	Messages    int
+	Blocks      int
+	Finality    int
+	Quarantined int
+	Unroutable  int
+	Pauses      int
+	Audits      int
+	Skipped     int
+	Overwrote   int
+}
+
+// Export writes all the messages, block heights, finality objects, quarantined and unroutable
+// messages, pauses and audit checkpoints as jsonl, if nId is not empty only the records of the
+// chain are exported
+func (r *Relayer) Export(w io.Writer, nId string) (*ExportStats, error) {
+	stats := new(ExportStats)
+	enc := json.NewEncoder(w)
+
+	version, err := NewMigrator(r.db).Version()
+	if err != nil {
+		return nil, err
+	}
+	if err := enc.Encode(ExportRecord{Kind: RecordHeader, SchemaVersion: version}); err != nil {
+		return nil, err
+	}
+
+	if err := exportMessages(enc, RecordMessage, r.messageStore, nId, &stats.Messages); err != nil {
+		return nil, err
+	}
+
+	heights, err := r.blockStore.GetLastStoredBlocks()
+	if err != nil {
+		return nil, err
+	}
+	for chain, height := range heights {
+		if nId != "" && chain != nId {
+			continue
+		}
+		if err := enc.Encode(ExportRecord{Kind: RecordBlock, Chain: chain, Height: height}); err != nil {
+			return nil, err
+		}
+		stats.Blocks++
+	}
+
+	txObjects, err := r.finalityStore.GetTxObjects(nId, store.NewPagination().GetAll())
+	if err != nil {
+		return nil, err
+	}
+	for _, txObj := range txObjects {
+		if nId != "" && txObj.Dst != nId {
+			continue
+		}
+		if err := enc.Encode(ExportRecord{Kind: RecordFinality, Chain: txObj.Dst, TxObject: txObj}); err != nil {
+			return nil, err
+		}
+		stats.Finality++
+	}
+
+	if err := exportMessages(enc, RecordQuarantined, r.quarantineStore, nId, &stats.Quarantined); err != nil {
+		return nil, err
+	}
+	if err := exportMessages(enc, RecordUnroutable, r.unroutableStore, nId, &stats.Unroutable); err != nil {
+		return nil, err
+	}
+
+	pauses, err := r.pauseStore.GetPauses()
+	if err != nil {
+		return nil, err
+	}
+	for _, pause := range pauses {
+		if nId != "" && pause.Src != nId {
+			continue
+		}
+		if err := enc.Encode(ExportRecord{Kind: RecordPause, Chain: pause.Src, Pause: pause}); err != nil {
+			return nil, err
+		}
+		stats.Pauses++
+	}
+
+	if err := r.exportAudits(enc, nId, stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// exportAudits writes the audit checkpoint and attempts of every audited chain as one record
+func (r *Relayer) exportAudits(enc *json.Encoder, nId string, stats *ExportStats) error {
+	audited, err := r.auditStore.GetLastStoredBlocks()
+	if err != nil {
+		return err
+	}
+	attempts, err := r.auditAttemptsStore.GetLastStoredBlocks()
+	if err != nil {
+		return err
+	}
+	chains := make([]string, 0, len(attempts))
+	for chain := range attempts {
+		chains = append(chains, chain)
+	}
+	for chain := range audited {
+		if _, ok := attempts[chain]; !ok {
+			chains = append(chains, chain)
+		}
+	}
+	for _, chain := range chains {
+		if nId != "" && chain != nId {
+			continue
+		}
+		if err := enc.Encode(ExportRecord{Kind: RecordAudit, Chain: chain, Height: audited[chain], Attempts: attempts[chain]}); err != nil {
+			return err
+		}
+		stats.Audits++
+	}
+	return nil
+}
+
+// exportMessages writes the messages of the store as records of the kind and counts them
+func exportMessages(enc *json.Encoder, kind string, ms *store.MessageStore, nId string, count *int) error {
+	messages, err := ms.GetMessages(nId, store.NewPagination().GetAll())
+	if err != nil {
+		return err
+	}
+	for _, m := range messages {
+		if nId != "" && m.Src != nId {
+			continue
+		}
+		if err := enc.Encode(ExportRecord{Kind: kind, Chain: m.Src, Message: m}); err != nil {
+			return err
+		}
+		*count++
+	}
+	return nil
+}
+
+// messageStoreOf returns the store of a message record kind and the count of its records
+func (r *Relayer) messageStoreOf(kind string, stats *ExportStats) (*store.MessageStore, *int) {
+	switch kind {
+	case RecordMessage:
+		return r.messageStore, &stats.Messages
+	case RecordQuarantined:
+		return r.quarantineStore, &stats.Quarantined
+	case RecordUnroutable:
+		return r.unroutableStore, &stats.Unroutable
+	}
+	return nil, nil
+}
+
+// Import loads the records written by Export into the store,
+// existing records are handled according to the conflict policy
+func (r *Relayer) Import(rd io.Reader, nId string, policy ConflictPolicy) (*ExportStats, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	stats := new(ExportStats)
+	latest := NewMigrator(r.db).LatestVersion()
+
+	scanner := bufio.NewScanner(rd)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var rec ExportRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return stats, fmt.Errorf("line %d: %w", line, err)
+		}
+		if rec.Kind != RecordHeader && nId != "" && rec.Chain != nId {
+			continue
+		}
+
+		var exists bool
+		switch rec.Kind {
+		case RecordHeader:
+			if rec.SchemaVersion > latest {
+				return stats, fmt.Errorf("export schema version %d is newer than supported version %d", rec.SchemaVersion, latest)
+			}
+			continue
+		case RecordMessage, RecordQuarantined, RecordUnroutable:
+			if rec.Message == nil || rec.Message.Message == nil {
+				return stats, fmt.Errorf("line %d: %s record without message", line, rec.Kind)
+			}
+			ms, _ := r.messageStoreOf(rec.Kind, stats)
+			_, err := ms.GetMessage(rec.Message.MessageKey())
+			exists = err == nil
+		case RecordBlock:
+			_, err := r.blockStore.GetLastStoredBlock(rec.Chain)
+			exists = err == nil
+		case RecordFinality:
+			if rec.TxObject == nil {
+				return stats, fmt.Errorf("line %d: finality record without transaction object", line)
+			}
+			_, err := r.finalityStore.GetTxObject(&rec.TxObject.MessageKey)
+			exists = err == nil
+		case RecordPause:
+			if rec.Pause == nil {
+				return stats, fmt.Errorf("line %d: pause record without pause", line)
+			}
+			_, err := r.pauseStore.GetPause(rec.Pause.Src, rec.Pause.Dst)
+			exists = err == nil
+		case RecordAudit:
+			_, err := r.auditStore.GetLastStoredBlock(rec.Chain)
+			_, attemptsErr := r.auditAttemptsStore.GetLastStoredBlock(rec.Chain)
+			exists = err == nil || attemptsErr == nil
+		default:
+			return stats, fmt.Errorf("line %d: unknown record kind %q", line, rec.Kind)
+		}
+
+		if exists {
+			switch policy {
+			case ConflictFail:
+				return stats, fmt.Errorf("line %d: %s record of chain %s already exists", line, rec.Kind, rec.Chain)
+			case ConflictSkip:
+				stats.Skipped++
+				continue
+			case ConflictOverwrite:
+				stats.Overwrote++
+			}
+		}
+
+		switch rec.Kind {
+		case RecordMessage, RecordQuarantined, RecordUnroutable:
+			ms, count := r.messageStoreOf(rec.Kind, stats)
+			if err := ms.StoreMessage(rec.Message); err != nil {
+				return stats, err
+			}
+			*count++
+		case RecordBlock:
+			if err := r.blockStore.StoreBlock(rec.Height, rec.Chain); err != nil {
+				return stats, err
+			}
+			stats.Blocks++
+		case RecordFinality:
+			if err := r.finalityStore.StoreTxObject(rec.TxObject); err != nil {
+				return stats, err
+			}
+			stats.Finality++
+		case RecordPause:
+			if err := r.pauseStore.StorePause(rec.Pause); err != nil {
+				return stats, err
+			}
+			stats.Pauses++
+		case RecordAudit:
+			// the sn start at 1, a chain without a checkpoint is exported with height 0
+			if rec.Height > 0 {
+				if err := r.auditStore.StoreBlock(rec.Height, rec.Chain); err != nil {
+					return stats, err
+				}
+			}
+			if err := r.auditAttemptsStore.StoreBlock(rec.Attempts, rec.Chain); err != nil {
+				return stats, err
+			}
+			stats.Audits++
+		}
+	}
+	return stats, scanner.Err()
+}
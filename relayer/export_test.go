@@ -0,0 +1,110 @@
+package relayer
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/memdb"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func newExportTestRelayer(t *testing.T) *Relayer {
+	rly, err := NewRelayer(zap.NewNop(), memdb.NewMemDB(), map[string]*Chain{}, true)
+	if err != nil {
+		assert.FailNow(t, "failed to create relayer", err)
+	}
+	return rly
+}
+
+func TestExportImport(t *testing.T) {
+	t.Parallel()
+	src := newExportTestRelayer(t)
+	dst := newExportTestRelayer(t)
+
+	m1 := types.NewRouteMessage(&types.Message{Src: "mock-1", Dst: "mock-2", Sn: 1, Data: []byte("one"), EventType: "emitMessage"})
+	m2 := types.NewRouteMessage(&types.Message{Src: "mock-2", Dst: "mock-1", Sn: 1, Data: []byte("two"), EventType: "emitMessage"})
+	assert.NoError(t, src.messageStore.StoreMessage(m1))
+	assert.NoError(t, src.messageStore.StoreMessage(m2))
+	assert.NoError(t, src.blockStore.StoreBlock(100, "mock-1"))
+	assert.NoError(t, src.blockStore.StoreBlock(200, "mock-2"))
+	txObj := types.NewTransactionObject(*types.NewMessagekeyWithMessageHeight(m1.MessageKey(), 10), "0xabc", 110)
+	assert.NoError(t, src.finalityStore.StoreTxObject(txObj))
+	quarantined := types.NewRouteMessage(&types.Message{Src: "mock-1", Dst: "mock-2", Sn: 2, Data: []byte("forged"), EventType: "emitMessage"})
+	assert.NoError(t, src.quarantineStore.StoreMessage(quarantined))
+	unroutable := types.NewRouteMessage(&types.Message{Src: "mock-2", Dst: "mock-3", Sn: 2, Data: []byte("parked"), EventType: "emitMessage"})
+	assert.NoError(t, src.unroutableStore.StoreMessage(unroutable))
+	pause := &types.Pause{Src: "mock-1", Dst: "mock-2", Reason: "upgrade", CreatedAt: time.Now().UTC().Round(time.Second)}
+	assert.NoError(t, src.pauseStore.StorePause(pause))
+	assert.NoError(t, src.storeAudited(true, "mock-1", 42, 0, false))
+	assert.NoError(t, src.storeAudited(true, "mock-2", 0, 1, true))
+
+	var buf bytes.Buffer
+	stats, err := src.Export(&buf, "")
+	assert.NoError(t, err)
+	assert.Equal(t, &ExportStats{Messages: 2, Blocks: 2, Finality: 1, Quarantined: 1, Unroutable: 1, Pauses: 1, Audits: 2}, stats)
+
+	t.Run("import into empty store", func(t *testing.T) {
+		stats, err := dst.Import(bytes.NewReader(buf.Bytes()), "", ConflictFail)
+		assert.NoError(t, err)
+		assert.Equal(t, &ExportStats{Messages: 2, Blocks: 2, Finality: 1, Quarantined: 1, Unroutable: 1, Pauses: 1, Audits: 2}, stats)
+
+		msg, err := dst.messageStore.GetMessage(m2.MessageKey())
+		assert.NoError(t, err)
+		assert.Equal(t, m2, msg)
+
+		height, err := dst.blockStore.GetLastStoredBlock("mock-2")
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(200), height)
+
+		obj, err := dst.finalityStore.GetTxObject(&txObj.MessageKey)
+		assert.NoError(t, err)
+		assert.Equal(t, txObj, obj)
+
+		// quarantined messages stay out of the relay path
+		msg, err = dst.quarantineStore.GetMessage(quarantined.MessageKey())
+		assert.NoError(t, err)
+		assert.Equal(t, quarantined, msg)
+		_, err = dst.messageStore.GetMessage(quarantined.MessageKey())
+		assert.Error(t, err)
+
+		msg, err = dst.unroutableStore.GetMessage(unroutable.MessageKey())
+		assert.NoError(t, err)
+		assert.Equal(t, unroutable, msg)
+
+		pauses, err := dst.pauseStore.GetPauses()
+		assert.NoError(t, err)
+		assert.Equal(t, []*types.Pause{pause}, pauses)
+
+		audited, err := dst.auditStore.GetLastStoredBlock("mock-1")
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(42), audited)
+		_, err = dst.auditStore.GetLastStoredBlock("mock-2")
+		assert.Error(t, err)
+		attempts, err := dst.auditAttemptsStore.GetLastStoredBlock("mock-2")
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(2), attempts)
+	})
+
+	t.Run("conflict policies", func(t *testing.T) {
+		_, err := dst.Import(bytes.NewReader(buf.Bytes()), "", ConflictFail)
+		assert.Error(t, err)
+
+		stats, err := dst.Import(bytes.NewReader(buf.Bytes()), "", ConflictSkip)
+		assert.NoError(t, err)
+		assert.Equal(t, 10, stats.Skipped)
+
+		stats, err = dst.Import(bytes.NewReader(buf.Bytes()), "", ConflictOverwrite)
+		assert.NoError(t, err)
+		assert.Equal(t, 10, stats.Overwrote)
+	})
+
+	t.Run("export by chain", func(t *testing.T) {
+		var chainBuf bytes.Buffer
+		stats, err := src.Export(&chainBuf, "mock-1")
+		assert.NoError(t, err)
+		assert.Equal(t, &ExportStats{Messages: 1, Blocks: 1, Finality: 0, Quarantined: 1, Pauses: 1, Audits: 1}, stats)
+	})
+}
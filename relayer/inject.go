@@ -0,0 +1,227 @@
+package relayer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/provider"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"go.uber.org/zap"
+)
+
+// DefaultInjectTimeout bounds the wait for the delivery of an injected message
+const DefaultInjectTimeout = 2 * time.Minute
+
+// InjectStatus is what became of a message of an injected transaction
+type InjectStatus string
+
+const (
+	// InjectReceived is a message which its destination already received
+	InjectReceived InjectStatus = "received"
+	// InjectPending is a message the relayer is already relaying
+	InjectPending InjectStatus = "pending"
+	// InjectUnroutable is a message to a chain which is not configured, it is parked until the chain is
+	InjectUnroutable InjectStatus = "unroutable"
+	// InjectQueued is a message stored for the relayer to deliver
+	InjectQueued InjectStatus = "queued"
+	// InjectRelayed is a message delivered by the injection
+	InjectRelayed InjectStatus = "relayed"
+	// InjectFailed is a message which delivery failed, it is queued for the relayer to retry
+	InjectFailed InjectStatus = "failed"
+	// InjectSubmitted is a message which transaction did not complete within the timeout,
+	// it is queued for the relayer only if the transaction fails
+	InjectSubmitted InjectStatus = "submitted"
+)
+
+// InjectOptions selects the source transaction of the messages to inject
+type InjectOptions struct {
+	Chain  string `json:"chain"`
+	TxHash string `json:"txHash"`
+	// RelayNow delivers the messages and waits for the destination transactions
+	RelayNow bool          `json:"relayNow,omitempty"`
+	Timeout  time.Duration `json:"timeout,omitempty"`
+}
+
+// InjectedMessage is a message of the injected transaction
+type InjectedMessage struct {
+	Src    string       `json:"src"`
+	Dst    string       `json:"dst"`
+	Sn     uint64       `json:"sn"`
+	Height uint64       `json:"height"`
+	Status InjectStatus `json:"status"`
+	// TxHash is the destination transaction of a message relayed by the injection
+	TxHash string `json:"txHash,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Inject parses the messages emitted by a source transaction and queues the ones which their
+// destination did not receive, with RelayNow they are delivered before Inject returns
+func (r *Relayer) Inject(ctx context.Context, opts InjectOptions) ([]*InjectedMessage, error) {
+	if opts.RelayNow && !r.leading() {
+		return nil, fmt.Errorf("the relayer is a standby, only the leader relays messages now")
+	}
+	src, err := r.FindChainRuntime(opts.Chain)
+	if err != nil {
+		return nil, err
+	}
+	finder, ok := src.Provider.(provider.TxMessageFinder)
+	if !ok {
+		return nil, fmt.Errorf("chain type %s cannot parse the messages of a transaction", src.Provider.Type())
+	}
+	messages, err := finder.TxMessages(ctx, opts.TxHash)
+	if err != nil {
+		return nil, err
+	}
+	if len(messages) == 0 {
+		return nil, fmt.Errorf("transaction %s emitted no message on %s", opts.TxHash, opts.Chain)
+	}
+	pending, err := r.pendingSn(src)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = DefaultInjectTimeout
+	}
+
+	injected := make([]*InjectedMessage, 0, len(messages))
+	for _, m := range messages {
+		result := &InjectedMessage{Src: m.Src, Dst: m.Dst, Sn: m.Sn, Height: m.MessageHeight}
+		injected = append(injected, result)
+		if pending[m.Sn] {
+			result.Status = InjectPending
+			continue
+		}
+		// kept until the route is relayed
+		if reason := r.unroutableReason(m.Src, m.Dst); reason != "" {
+			r.parkUnroutable(types.NewRouteMessage(m), src, reason)
+			result.Status = InjectUnroutable
+			continue
+		}
+		dst, err := r.FindChainRuntime(m.Dst)
+		if err != nil {
+			return injected, err
+		}
+		received, err := dst.Provider.MessageReceived(ctx, m.MessageKey())
+		if err != nil {
+			return injected, fmt.Errorf("receipt of sn %d on %s: %w", m.Sn, m.Dst, err)
+		}
+		if received {
+			result.Status = InjectReceived
+			continue
+		}
+
+		relayNow := opts.RelayNow
+		if reason := r.holdBack(ctx, src, dst, m); relayNow && reason != "" {
+			result.Error = fmt.Sprintf("not relayed now, %s", reason)
+			relayNow = false
+		}
+		if !relayNow {
+			if err := r.queueMessage(src, m); err != nil {
+				return injected, fmt.Errorf("failed to queue sn %d: %w", m.Sn, err)
+			}
+			result.Status = InjectQueued
+			continue
+		}
+		// the message is only stored when the delivery fails, the router of a running relayer must not send it twice
+		response, err := r.relayNow(ctx, src, dst, m, opts.Timeout)
+		result.TxHash = response.TxHash
+		switch {
+		case errors.Is(err, errRelayPending):
+			result.Status, result.Error = InjectSubmitted, err.Error()
+		case err != nil:
+			result.Status, result.Error = InjectFailed, err.Error()
+			if err := r.queueMessage(src, m); err != nil {
+				return injected, fmt.Errorf("failed to queue sn %d: %w", m.Sn, err)
+			}
+		default:
+			result.Status = InjectRelayed
+			r.storeFinalityTx(ctx, dst, m, response)
+		}
+	}
+	return injected, nil
+}
+
+// holdBack returns why the router would not send the message now, empty when it would
+func (r *Relayer) holdBack(ctx context.Context, src, dst *ChainRuntime, m *types.Message) string {
+	switch {
+	case dst.routingPaused():
+		return "the balance of the destination wallets is critical"
+	case r.routePaused(m.Src, m.Dst):
+		return "the route is paused"
+	case !dst.shouldSendMessage(ctx, types.NewRouteMessage(m), src):
+		return "the chains do not accept the message yet"
+	}
+	return ""
+}
+
+// errRelayPending is returned when the transaction of a message relayed now did not complete in time
+var errRelayPending = errors.New("the transaction did not complete in time, the message is queued if it fails")
+
+type relayResult struct {
+	response types.TxResponse
+	err      error
+}
+
+// relayNow routes the message to dst and waits for the result of its transaction, a result
+// coming after the timeout is settled in the background
+func (r *Relayer) relayNow(ctx context.Context, src, dst *ChainRuntime, m *types.Message, timeout time.Duration) (types.TxResponse, error) {
+	// the transaction is followed beyond the request, it may complete after the timeout
+	routeCtx := r.ctx
+	if routeCtx == nil {
+		routeCtx = ctx
+	}
+	done := make(chan relayResult, 1)
+	err := dst.Provider.Route(routeCtx, m, func(key types.MessageKey, response types.TxResponse, err error) {
+		if err == nil && response.Code != types.Success {
+			err = fmt.Errorf("transaction %s failed with code %d", response.TxHash, response.Code)
+		}
+		done <- relayResult{response, err}
+	})
+	if err != nil {
+		return types.TxResponse{}, err
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case res := <-done:
+		return res.response, res.err
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+	go r.settleRelayNow(routeCtx, src, dst, m, done)
+	// a late result does not mean the message was not delivered
+	if received, err := dst.Provider.MessageReceived(routeCtx, m.MessageKey()); err == nil && received {
+		return types.TxResponse{}, nil
+	}
+	return types.TxResponse{}, fmt.Errorf("sn %d: %w", m.Sn, errRelayPending)
+}
+
+// settleRelayNow handles the late result of a message relayed now, the message is queued when its transaction failed
+func (r *Relayer) settleRelayNow(ctx context.Context, src, dst *ChainRuntime, m *types.Message, done <-chan relayResult) {
+	select {
+	case <-ctx.Done():
+	case res := <-done:
+		if res.err == nil {
+			r.storeFinalityTx(ctx, dst, m, res.response)
+			return
+		}
+		r.log.Warn("late delivery of an injected message failed, queueing it", zap.String("src", m.Src), zap.String("dst", m.Dst), zap.Uint64("sn", m.Sn), zap.Error(res.err))
+		if err := r.queueMessage(src, m); err != nil {
+			r.log.Error("failed to queue injected message", zap.String("src", m.Src), zap.Uint64("sn", m.Sn), zap.Error(err))
+		}
+	}
+}
+
+// storeFinalityTx keeps the destination transaction of a delivered message for the finality processor
+func (r *Relayer) storeFinalityTx(ctx context.Context, dst *ChainRuntime, m *types.Message, response types.TxResponse) {
+	if dst.Provider.FinalityBlock(ctx) == 0 {
+		return
+	}
+	txObj := types.NewTransactionObject(*types.NewMessagekeyWithMessageHeight(m.MessageKey(), m.MessageHeight), response.TxHash, uint64(response.Height))
+	if err := r.finalityStore.StoreTxObject(txObj); err != nil {
+		r.log.Error("error occured: while storing transaction object in db", zap.Any("txObj", txObj), zap.Error(err))
+	}
+}
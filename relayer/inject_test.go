@@ -0,0 +1,147 @@
+package relayer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/memdb"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func (p *snProvider) TxMessages(ctx context.Context, txHash string) ([]*types.Message, error) {
+	height, ok := p.txs[txHash]
+	if !ok {
+		return nil, fmt.Errorf("transaction %s not found", txHash)
+	}
+	var messages []*types.Message
+	for _, m := range p.messages {
+		if m.MessageHeight == height {
+			messages = append(messages, m)
+		}
+	}
+	return messages, nil
+}
+
+func (p *snProvider) Route(ctx context.Context, m *types.Message, callback types.TxResponseFunc) error {
+	if p.routeErr != nil {
+		return p.routeErr
+	}
+	if p.late != nil {
+		go func() {
+			callback(m.MessageKey(), <-p.late, nil)
+		}()
+		return nil
+	}
+	p.received[m.Sn] = true
+	callback(m.MessageKey(), types.TxResponse{Code: types.Success, TxHash: fmt.Sprintf("0xdst%d", m.Sn)}, nil)
+	return nil
+}
+
+func TestInject(t *testing.T) {
+	log := zap.NewNop()
+	src := newSnProvider(t, log, "mock-1", "mock-2", 50)
+	dst := newSnProvider(t, log, "mock-2", "mock-1", 50)
+	src.emit("mock-2", 10) // 1 received
+	src.emit("mock-2", 10) // 2 missed
+	src.emit("other", 10)  // 3 to a chain which is not configured
+	src.emit("mock-2", 20) // 4 relayed now
+	src.emit("mock-2", 30) // 5 failing
+	src.txs = map[string]uint64{"0x10": 10, "0x20": 20, "0x30": 30, "0x40": 40}
+	dst.received[1] = true
+
+	rly, err := NewRelayer(log, memdb.NewMemDB(), map[string]*Chain{
+		"mock-1": NewChain(log, src, true),
+		"mock-2": NewChain(log, dst, true),
+	}, true)
+	require.NoError(t, err)
+	runtime, err := rly.FindChainRuntime("mock-1")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	injected, err := rly.Inject(ctx, InjectOptions{Chain: "mock-1", TxHash: "0x10"})
+	require.NoError(t, err)
+	require.Len(t, injected, 3)
+	assert.Equal(t, InjectReceived, injected[0].Status)
+	assert.Equal(t, InjectQueued, injected[1].Status)
+	assert.Equal(t, InjectUnroutable, injected[2].Status)
+	_, err = rly.unroutableStore.GetMessage(src.messages[2].MessageKey())
+	assert.NoError(t, err)
+	_, err = rly.messageStore.GetMessage(src.messages[1].MessageKey())
+	assert.NoError(t, err)
+	assert.Contains(t, runtime.MessageCache.Messages, src.messages[1].MessageKey())
+
+	// a queued message is not injected twice
+	injected, err = rly.Inject(ctx, InjectOptions{Chain: "mock-1", TxHash: "0x10"})
+	require.NoError(t, err)
+	assert.Equal(t, InjectPending, injected[1].Status)
+
+	// relayed now without going through the store
+	injected, err = rly.Inject(ctx, InjectOptions{Chain: "mock-1", TxHash: "0x20", RelayNow: true})
+	require.NoError(t, err)
+	require.Len(t, injected, 1)
+	assert.Equal(t, InjectRelayed, injected[0].Status)
+	assert.Equal(t, "0xdst4", injected[0].TxHash)
+	assert.True(t, dst.received[4])
+	_, err = rly.messageStore.GetMessage(src.messages[3].MessageKey())
+	assert.Error(t, err)
+
+	// a failed delivery is left to the relayer
+	dst.routeErr = errors.New("out of gas")
+	injected, err = rly.Inject(ctx, InjectOptions{Chain: "mock-1", TxHash: "0x30", RelayNow: true})
+	require.NoError(t, err)
+	assert.Equal(t, InjectFailed, injected[0].Status)
+	assert.Equal(t, "out of gas", injected[0].Error)
+	_, err = rly.messageStore.GetMessage(src.messages[4].MessageKey())
+	assert.NoError(t, err)
+
+	_, err = rly.Inject(ctx, InjectOptions{Chain: "mock-1", TxHash: "0x40"})
+	assert.Error(t, err)
+	_, err = rly.Inject(ctx, InjectOptions{Chain: "mock-1", TxHash: "0x50"})
+	assert.Error(t, err)
+}
+
+func TestInjectRelayNowGuards(t *testing.T) {
+	log := zap.NewNop()
+	src := newSnProvider(t, log, "mock-1", "mock-2", 50)
+	dst := newSnProvider(t, log, "mock-2", "mock-1", 50)
+	src.emit("mock-2", 10) // 1 on a paused route
+	src.emit("mock-2", 20) // 2 completing after the timeout
+	src.txs = map[string]uint64{"0x10": 10, "0x20": 20}
+
+	rly, err := NewRelayer(log, memdb.NewMemDB(), map[string]*Chain{
+		"mock-1": NewChain(log, src, true),
+		"mock-2": NewChain(log, dst, true),
+	}, true)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// a paused route is not relayed now, the message waits for the resume
+	require.NoError(t, rly.Pause(types.Pause{Src: "mock-1", Dst: "mock-2"}))
+	injected, err := rly.Inject(ctx, InjectOptions{Chain: "mock-1", TxHash: "0x10", RelayNow: true})
+	require.NoError(t, err)
+	assert.Equal(t, InjectQueued, injected[0].Status)
+	assert.Contains(t, injected[0].Error, "paused")
+	assert.False(t, dst.received[1])
+	require.NoError(t, rly.Resume("mock-1", "mock-2"))
+
+	// a transaction outliving the timeout is not queued while it may still succeed
+	dst.late = make(chan types.TxResponse)
+	injected, err = rly.Inject(ctx, InjectOptions{Chain: "mock-1", TxHash: "0x20", RelayNow: true, Timeout: 10 * time.Millisecond})
+	require.NoError(t, err)
+	assert.Equal(t, InjectSubmitted, injected[0].Status)
+	_, err = rly.messageStore.GetMessage(src.messages[1].MessageKey())
+	assert.Error(t, err)
+
+	// and queued once it failed
+	dst.late <- types.TxResponse{Code: types.Failed, TxHash: "0xdst2"}
+	assert.Eventually(t, func() bool {
+		_, err := rly.messageStore.GetMessage(src.messages[1].MessageKey())
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+}
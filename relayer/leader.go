@@ -0,0 +1,186 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/lease"
+	"github.com/icon-project/centralized-relay/relayer/metrics"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"go.uber.org/zap"
+)
+
+// DefaultLeaseTTL is how long the leadership lease outlives its last renewal,
+// a standby takes over within this duration after the leader stops
+const DefaultLeaseTTL = 15 * time.Second
+
+// releaseTimeout bounds the release of the lease when the relayer stops
+const releaseTimeout = 5 * time.Second
+
+// StandbyPruneInterval is how often a standby drops the cached messages the leader delivered
+var StandbyPruneInterval = 30 * time.Second
+
+// LeaderElectionConfig configures the active/passive mode of the relayer instances sharing a lease.
+// Only the lease is shared, every instance keeps its own database and listens to the chains itself,
+// so a new leader relays from what it saw as a standby rather than from the state of the former leader
+type LeaderElectionConfig struct {
+	Lease lease.Lease
+	// ID names the instance in the lease, it must differ between the instances
+	ID  string
+	TTL time.Duration
+	// RenewInterval is how often the lease is renewed, a third of the ttl when zero
+	RenewInterval time.Duration
+}
+
+// minLeaseRenewals is the number of renewals the ttl must cover, a leader which misses
+// a renewal stops relaying well before a standby may take the lease over
+const minLeaseRenewals = 3
+
+func (c *LeaderElectionConfig) setDefaults() {
+	if c.TTL == 0 {
+		c.TTL = DefaultLeaseTTL
+	}
+	if c.RenewInterval == 0 {
+		c.RenewInterval = c.TTL / minLeaseRenewals
+	}
+}
+
+// Validate checks that the ttl is long enough for the renew interval
+func (c LeaderElectionConfig) Validate() error {
+	c.setDefaults()
+	if c.RenewInterval <= 0 {
+		return fmt.Errorf("lease renew interval must be positive")
+	}
+	if c.TTL < minLeaseRenewals*c.RenewInterval {
+		return fmt.Errorf("lease ttl %s must be at least %d times the renew interval %s", c.TTL, minLeaseRenewals, c.RenewInterval)
+	}
+	return nil
+}
+
+// WithLeaderElection starts the relayer as a standby which routes messages only while it holds the lease
+func WithLeaderElection(cfg LeaderElectionConfig) Option {
+	return func(ctx context.Context, r *Relayer) {
+		r.standby.Store(true)
+		metrics.Leader.Set(0)
+		go r.StartLeaderElection(ctx, cfg)
+	}
+}
+
+// StartLeaderElection competes for the lease until ctx is done, the lease is renewed every renew interval
+func (r *Relayer) StartLeaderElection(ctx context.Context, cfg LeaderElectionConfig) {
+	cfg.setDefaults()
+	log := r.log.With(zap.String("component", "leader"), zap.String("id", cfg.ID))
+	ticker := time.NewTicker(cfg.RenewInterval)
+	defer ticker.Stop()
+	pruneTicker := time.NewTicker(StandbyPruneInterval)
+	defer pruneTicker.Stop()
+	for {
+		r.campaign(ctx, cfg, log)
+		select {
+		case <-ctx.Done():
+			r.resign(cfg, log)
+			return
+		case <-pruneTicker.C:
+			if r.standby.Load() {
+				r.pruneDelivered(ctx, log)
+			}
+		case <-ticker.C:
+		}
+	}
+}
+
+// pruneDelivered drops the messages the destinations received from the cache and the db of a standby,
+// so its cache does not grow while the leader relays and the takeover only checks the undelivered ones
+func (r *Relayer) pruneDelivered(ctx context.Context, log *zap.Logger) {
+	var pruned int
+	for _, src := range r.chainRuntimes() {
+		for _, routeMessage := range src.MessageCache.Snapshot() {
+			if !r.standby.Load() {
+				return
+			}
+			dst, err := r.FindChainRuntime(routeMessage.Dst)
+			if err != nil {
+				continue
+			}
+			key := routeMessage.MessageKey()
+			received, err := dst.Provider.MessageReceived(ctx, key)
+			if err != nil {
+				log.Debug("failed to check the receipt of a cached message", zap.Any("message-key", key), zap.Error(err))
+				continue
+			}
+			if !received {
+				continue
+			}
+			if err := r.ClearMessages(ctx, []types.MessageKey{key}, src); err != nil {
+				log.Error("failed to clear delivered message", zap.Any("message-key", key), zap.Error(err))
+				continue
+			}
+			pruned++
+		}
+	}
+	if pruned > 0 {
+		log.Debug("pruned messages delivered by the leader", zap.Int("count", pruned))
+	}
+}
+
+// campaign acquires or renews the lease and switches the role of the relayer when it changed
+func (r *Relayer) campaign(ctx context.Context, cfg LeaderElectionConfig, log *zap.Logger) {
+	start := time.Now()
+	leader, err := cfg.Lease.Acquire(ctx, cfg.ID, cfg.TTL)
+	if err != nil {
+		// the lease may expire before it is renewed, routing stops so that two leaders never relay
+		log.Error("failed to acquire the leadership lease", zap.Error(err))
+		leader = false
+	}
+	if leader {
+		// the standbys wait the ttl from when they saw the renewal, which is after start
+		deadline := start.Add(cfg.TTL - cfg.RenewInterval)
+		r.leaseDeadline.Store(&deadline)
+	}
+	if leader != r.standby.Load() {
+		return
+	}
+	r.standby.Store(!leader)
+	if !leader {
+		metrics.Leader.Set(0)
+		log.Warn("lost the leadership lease, standing by")
+		return
+	}
+	metrics.Leader.Set(1)
+	log.Info("acquired the leadership lease, relaying messages")
+	r.takeOver(ctx)
+}
+
+// leading is true while the relayer may route messages, a leader stops by itself once its
+// lease may have expired, even while the renewal is blocked
+func (r *Relayer) leading() bool {
+	if r.standby.Load() {
+		return false
+	}
+	deadline := r.leaseDeadline.Load()
+	return deadline == nil || time.Now().Before(*deadline)
+}
+
+// takeOver queues the messages persisted by the relayer, the listeners kept the cache warm
+// meanwhile and the router skips the messages the former leader delivered
+func (r *Relayer) takeOver(ctx context.Context) {
+	r.forgetFlushes()
+	r.flushMessages(ctx)
+	r.requeueUnroutable()
+}
+
+// resign releases the lease of a stopping leader so a standby takes over without waiting for the ttl
+func (r *Relayer) resign(cfg LeaderElectionConfig, log *zap.Logger) {
+	if r.standby.Swap(true) {
+		return
+	}
+	metrics.Leader.Set(0)
+	ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+	if err := cfg.Lease.Release(ctx, cfg.ID); err != nil {
+		log.Error("failed to release the leadership lease", zap.Error(err))
+		return
+	}
+	log.Info("released the leadership lease")
+}
@@ -0,0 +1,112 @@
+package relayer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/lease"
+	"github.com/icon-project/centralized-relay/relayer/memdb"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestLeaderElection(t *testing.T) {
+	log := zap.NewNop()
+	shared := lease.NewMemory()
+	ctx := context.Background()
+	newRelayer := func(id string) (*Relayer, LeaderElectionConfig) {
+		rly, err := NewRelayer(log, memdb.NewMemDB(), map[string]*Chain{
+			"mock-1": newClosingChain(t, log, "mock-1", "mock-2"),
+		}, true)
+		require.NoError(t, err)
+		rly.standby.Store(true)
+		return rly, LeaderElectionConfig{Lease: shared, ID: id, TTL: time.Minute}
+	}
+	a, cfgA := newRelayer("a")
+	b, cfgB := newRelayer("b")
+
+	a.campaign(ctx, cfgA, log)
+	b.campaign(ctx, cfgB, log)
+	assert.False(t, a.standby.Load())
+	assert.True(t, b.standby.Load())
+	assert.Equal(t, "a", shared.Holder())
+
+	// the standby keeps its messages without routing them
+	src, err := b.FindChainRuntime("mock-1")
+	require.NoError(t, err)
+	cached := types.NewRouteMessage(&types.Message{Src: "mock-1", Dst: "mock-3", Sn: 1})
+	src.MessageCache.Add(cached)
+	b.processMessages(ctx)
+	assert.Contains(t, src.MessageCache.Messages, cached.MessageKey())
+	_, err = b.unroutableStore.GetMessage(cached.MessageKey())
+	assert.Error(t, err)
+	stored := types.NewRouteMessage(&types.Message{Src: "mock-1", Dst: "mock-2", Sn: 2})
+	require.NoError(t, b.messageStore.StoreMessage(stored))
+	_, err = b.Inject(ctx, InjectOptions{Chain: "mock-1", TxHash: "0x1", RelayNow: true})
+	assert.Error(t, err)
+
+	// the standby takes over the persisted messages once the leader stops renewing the lease
+	shared.Expire()
+	b.campaign(ctx, cfgB, log)
+	assert.False(t, b.standby.Load())
+	assert.Contains(t, src.MessageCache.Messages, stored.MessageKey())
+	a.campaign(ctx, cfgA, log)
+	assert.True(t, a.standby.Load())
+
+	// a stopping leader hands the lease over
+	b.resign(cfgB, log)
+	assert.True(t, b.standby.Load())
+	assert.Empty(t, shared.Holder())
+	a.campaign(ctx, cfgA, log)
+	assert.False(t, a.standby.Load())
+}
+
+func TestPruneDelivered(t *testing.T) {
+	log := zap.NewNop()
+	src := newSnProvider(t, log, "mock-1", "mock-2", 10)
+	dst := newSnProvider(t, log, "mock-2", "mock-1", 10)
+	dst.received[1] = true
+	rly, err := NewRelayer(log, memdb.NewMemDB(), map[string]*Chain{
+		"mock-1": NewChain(log, src, true),
+		"mock-2": NewChain(log, dst, true),
+	}, true)
+	require.NoError(t, err)
+	rly.standby.Store(true)
+	runtime, err := rly.FindChainRuntime("mock-1")
+	require.NoError(t, err)
+	delivered := types.NewRouteMessage(&types.Message{Src: "mock-1", Dst: "mock-2", Sn: 1})
+	pending := types.NewRouteMessage(&types.Message{Src: "mock-1", Dst: "mock-2", Sn: 2})
+	for _, m := range []*types.RouteMessage{delivered, pending} {
+		require.NoError(t, rly.messageStore.StoreMessage(m))
+		runtime.MessageCache.Add(m)
+	}
+
+	rly.pruneDelivered(context.Background(), log)
+	assert.NotContains(t, runtime.MessageCache.Messages, delivered.MessageKey())
+	_, err = rly.messageStore.GetMessage(delivered.MessageKey())
+	assert.Error(t, err)
+	assert.Contains(t, runtime.MessageCache.Messages, pending.MessageKey())
+}
+
+func TestLeaseDeadline(t *testing.T) {
+	assert.NoError(t, LeaderElectionConfig{}.Validate())
+	assert.NoError(t, LeaderElectionConfig{TTL: 15 * time.Second, RenewInterval: 5 * time.Second}.Validate())
+	assert.Error(t, LeaderElectionConfig{TTL: 10 * time.Second, RenewInterval: 5 * time.Second}.Validate())
+
+	log := zap.NewNop()
+	rly, err := NewRelayer(log, memdb.NewMemDB(), map[string]*Chain{}, true)
+	require.NoError(t, err)
+	assert.True(t, rly.leading())
+	rly.standby.Store(true)
+	cfg := LeaderElectionConfig{Lease: lease.NewMemory(), ID: "a", TTL: 90 * time.Millisecond, RenewInterval: 30 * time.Millisecond}
+	rly.campaign(context.Background(), cfg, log)
+	assert.True(t, rly.leading())
+
+	// a leader which cannot renew stops relaying before the lease expires for the standbys
+	time.Sleep(70 * time.Millisecond)
+	assert.False(t, rly.standby.Load())
+	assert.False(t, rly.leading())
+}
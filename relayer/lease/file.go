@@ -0,0 +1,89 @@
+package lease
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// File is a lease kept in a file on storage shared by the relayer instances, the file is
+// locked while the lease is read and written so a single instance holds it at a time.
+// An instance takes the lease over once it has not been renewed for its ttl as measured
+// by the instance itself
+type File struct {
+	path string
+	now  func() time.Time
+	// mu guards the observation of the lease
+	mu       sync.Mutex
+	observed observer
+}
+
+var _ Lease = (*File)(nil)
+
+func NewFile(path string) *File {
+	return &File{path: path, now: time.Now}
+}
+
+func (f *File) Acquire(ctx context.Context, id string, ttl time.Duration) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.update(func(rec *record) bool {
+		return f.observed.acquire(rec, id, ttl, f.now())
+	})
+}
+
+func (f *File) Release(ctx context.Context, id string) error {
+	_, err := f.update(func(rec *record) bool {
+		if rec.Holder != id {
+			return false
+		}
+		rec.Holder = ""
+		rec.Version++
+		return true
+	})
+	return err
+}
+
+// update applies fn to the lease under the file lock, the lease is written when fn returns true
+func (f *File) update(fn func(*record) bool) (bool, error) {
+	file, err := os.OpenFile(f.path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return false, fmt.Errorf("failed to open lease file: %w", err)
+	}
+	defer file.Close()
+	if err := lockFile(file); err != nil {
+		return false, fmt.Errorf("failed to lock lease file: %w", err)
+	}
+	defer unlockFile(file)
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return false, fmt.Errorf("failed to read lease file: %w", err)
+	}
+	var rec record
+	// a lease torn by a crash while writing is free
+	if len(data) > 0 && json.Unmarshal(data, &rec) != nil {
+		rec = record{}
+	}
+	if !fn(&rec) {
+		return false, nil
+	}
+
+	if data, err = json.Marshal(rec); err != nil {
+		return false, err
+	}
+	if err := file.Truncate(0); err != nil {
+		return false, fmt.Errorf("failed to write lease file: %w", err)
+	}
+	if _, err := file.WriteAt(data, 0); err != nil {
+		return false, fmt.Errorf("failed to write lease file: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		return false, fmt.Errorf("failed to sync lease file: %w", err)
+	}
+	return true, nil
+}
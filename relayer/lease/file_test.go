@@ -0,0 +1,59 @@
+package lease
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "relayer.lease")
+	now := time.Unix(1000, 0)
+	a, b := NewFile(path), NewFile(path)
+	a.now = func() time.Time { return now }
+	// the clock of b is far ahead, the lease must not depend on it
+	b.now = func() time.Time { return now.Add(time.Hour) }
+	ctx := context.Background()
+	ttl := 10 * time.Second
+
+	ok, err := a.Acquire(ctx, "a", ttl)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = b.Acquire(ctx, "b", ttl)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// a renewal restarts the ttl seen by b
+	now = now.Add(8 * time.Second)
+	ok, err = a.Acquire(ctx, "a", ttl)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	now = now.Add(8 * time.Second)
+	ok, err = b.Acquire(ctx, "b", ttl)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// b takes over once the lease was not renewed for the ttl
+	now = now.Add(9 * time.Second)
+	ok, err = b.Acquire(ctx, "b", ttl)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	now = now.Add(2 * time.Second)
+	ok, err = b.Acquire(ctx, "b", ttl)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// only the holder releases the lease
+	require.NoError(t, a.Release(ctx, "a"))
+	ok, err = a.Acquire(ctx, "a", ttl)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	require.NoError(t, b.Release(ctx, "b"))
+	ok, err = a.Acquire(ctx, "a", ttl)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
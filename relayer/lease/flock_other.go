@@ -0,0 +1,16 @@
+//go:build !unix
+
+package lease
+
+import (
+	"errors"
+	"os"
+)
+
+func lockFile(file *os.File) error {
+	return errors.New("file leases are not supported on this platform")
+}
+
+func unlockFile(file *os.File) error {
+	return nil
+}
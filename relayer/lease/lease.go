@@ -0,0 +1,45 @@
+package lease
+
+import (
+	"context"
+	"time"
+)
+
+// Lease is the leadership lease relayer instances compete for, the holder keeps it by
+// acquiring it again before the ttl elapses. The expiry must not depend on the clocks of
+// the instances agreeing, stores like etcd or consul implement it with their own ttl leases
+type Lease interface {
+	// Acquire takes the lease for id when it is free or expired and renews it when id holds it,
+	// it reports false when another holder has the lease
+	Acquire(ctx context.Context, id string, ttl time.Duration) (bool, error)
+	// Release gives the lease up when id holds it
+	Release(ctx context.Context, id string) error
+}
+
+// record is the holder of a lease, Version changes with every renewal
+type record struct {
+	Holder  string        `json:"holder"`
+	Version uint64        `json:"version"`
+	TTL     time.Duration `json:"ttl"`
+}
+
+// observer judges the expiry of a record by how long its version has not changed on the
+// local monotonic clock, so the clocks of the hosts sharing the record do not matter
+type observer struct {
+	version uint64
+	seenAt  time.Time
+}
+
+// acquire takes the record for id when it is free, held by id or expired
+func (o *observer) acquire(rec *record, id string, ttl time.Duration, now time.Time) bool {
+	if o.seenAt.IsZero() || rec.Version != o.version {
+		o.version, o.seenAt = rec.Version, now
+	}
+	if rec.Holder != "" && rec.Holder != id && now.Sub(o.seenAt) < rec.TTL {
+		return false
+	}
+	rec.Holder, rec.TTL = id, ttl
+	rec.Version++
+	o.version, o.seenAt = rec.Version, now
+	return true
+}
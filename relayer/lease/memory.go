@@ -0,0 +1,57 @@
+package lease
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Memory is an in-process lease shared by the relayers of a test
+type Memory struct {
+	mu      sync.Mutex
+	holder  string
+	expires time.Time
+}
+
+var _ Lease = (*Memory)(nil)
+
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+func (m *Memory) Acquire(ctx context.Context, id string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	if m.holder != "" && m.holder != id && now.Before(m.expires) {
+		return false, nil
+	}
+	m.holder, m.expires = id, now.Add(ttl)
+	return true, nil
+}
+
+func (m *Memory) Release(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.holder == id {
+		m.holder = ""
+	}
+	return nil
+}
+
+// Holder returns the holder of the lease, empty when it is free or expired
+func (m *Memory) Holder() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if time.Now().Before(m.expires) {
+		return m.holder
+	}
+	return ""
+}
+
+// Expire ends the lease as if its holder stopped renewing it
+func (m *Memory) Expire() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.holder = ""
+}
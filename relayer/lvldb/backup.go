@@ -0,0 +1,180 @@
+package lvldb
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+const (
+	backupPrefix     = "backup-"
+	backupTimeFormat = "20060102T150405.000000000Z"
+	backupBatchSize  = 1000
+)
+
+// Backup copies a consistent snapshot of the database into a new timestamped directory
+// under dir and verifies it by reopening it readonly.
+// only the latest keep backups are retained, keep of 0 disables rotation
+func (db *LVLDB) Backup(dir string, keep int) (string, error) {
+	snapshot, err := db.SnapShot()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to take snapshot")
+	}
+	defer snapshot.Release()
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+	target := filepath.Join(dir, backupPrefix+time.Now().UTC().Format(backupTimeFormat))
+
+	iter := snapshot.NewIterator(nil, nil)
+	count, err := copyTo(target, iter)
+	if err != nil {
+		os.RemoveAll(target)
+		return "", err
+	}
+
+	if err := verifyBackup(target, count); err != nil {
+		os.RemoveAll(target)
+		return "", err
+	}
+
+	if keep > 0 {
+		if err := rotateBackups(dir, keep); err != nil {
+			return target, err
+		}
+	}
+	return target, nil
+}
+
+// Restore replaces the database with the backup. The backup is copied into a directory next
+// to the database which is swapped in once verified, a crash leaves either of them in place.
+// The open database holds the lock of its directory, no relayer can use it meanwhile
+func (db *LVLDB) Restore(backupPath string) error {
+	backup, err := leveldb.OpenFile(backupPath, &opt.Options{ReadOnly: true, ErrorIfMissing: true})
+	if err != nil {
+		return errors.Wrapf(err, "failed to open backup %s", backupPath)
+	}
+	suffix := time.Now().UTC().Format(backupTimeFormat)
+	restored := db.path + ".restore-" + suffix
+	count, err := copyTo(restored, backup.NewIterator(nil, nil))
+	backup.Close()
+	if err == nil {
+		err = verifyBackup(restored, count)
+	}
+	if err != nil {
+		os.RemoveAll(restored)
+		return err
+	}
+
+	db.Lock()
+	defer db.Unlock()
+	// the replaced database stays open until the restored one is in place, it keeps the lock meanwhile
+	replaced := db.path + ".replaced-" + suffix
+	if err := os.Rename(db.path, replaced); err != nil {
+		os.RemoveAll(restored)
+		return err
+	}
+	if err := os.Rename(restored, db.path); err != nil {
+		if rerr := os.Rename(replaced, db.path); rerr != nil {
+			return fmt.Errorf("failed to swap in the restored database: %w, the database was left at %s: %v", err, replaced, rerr)
+		}
+		os.RemoveAll(restored)
+		return err
+	}
+	ldb, err := leveldb.OpenFile(db.path, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to open the restored database")
+	}
+	db.db.Close()
+	db.db = ldb
+	return os.RemoveAll(replaced)
+}
+
+// ListBackups returns the backups found in dir, oldest first
+func ListBackups(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var backups []string
+	for _, e := range entries {
+		if e.IsDir() && strings.HasPrefix(e.Name(), backupPrefix) {
+			backups = append(backups, filepath.Join(dir, e.Name()))
+		}
+	}
+	// timestamp format keeps the lexical order chronological
+	sort.Strings(backups)
+	return backups, nil
+}
+
+func copyTo(target string, iter iterator.Iterator) (int, error) {
+	defer iter.Release()
+
+	out, err := leveldb.OpenFile(target, nil)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to create backup %s", target)
+	}
+	defer out.Close()
+
+	count := 0
+	batch := new(leveldb.Batch)
+	for iter.Next() {
+		batch.Put(iter.Key(), iter.Value())
+		count++
+		if batch.Len() >= backupBatchSize {
+			if err := out.Write(batch, nil); err != nil {
+				return 0, err
+			}
+			batch.Reset()
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return 0, err
+	}
+	return count, out.Write(batch, nil)
+}
+
+func verifyBackup(target string, expected int) error {
+	backup, err := NewLvlDB(target, true)
+	if err != nil {
+		return errors.Wrap(err, "failed to verify backup")
+	}
+	defer backup.Close()
+
+	iter := backup.NewIterator(nil)
+	count := 0
+	for iter.Next() {
+		count++
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	if count != expected {
+		return fmt.Errorf("backup verification failed: expected %d keys, found %d", expected, count)
+	}
+	return nil
+}
+
+func rotateBackups(dir string, keep int) error {
+	backups, err := ListBackups(dir)
+	if err != nil {
+		return err
+	}
+	for len(backups) > keep {
+		if err := os.RemoveAll(backups[0]); err != nil {
+			return err
+		}
+		backups = backups[1:]
+	}
+	return nil
+}
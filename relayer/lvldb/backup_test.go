@@ -0,0 +1,65 @@
+package lvldb
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackupRestore(t *testing.T) {
+	dbName, backupDir := "./testdb-backup", "./testdb-backups"
+	db, err := NewLvlDB(dbName, false)
+	if err != nil {
+		assert.FailNow(t, "error while creating test db ", err)
+	}
+	defer func() {
+		db.Close()
+		os.RemoveAll(dbName)
+		os.RemoveAll(backupDir)
+	}()
+
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, db.SetByKey([]byte(fmt.Sprintf("key-%d", i)), []byte(fmt.Sprintf("value-%d", i))))
+	}
+
+	path, err := db.Backup(backupDir, 2)
+	assert.NoError(t, err)
+
+	t.Run("backups are rotated", func(t *testing.T) {
+		_, err := db.Backup(backupDir, 2)
+		assert.NoError(t, err)
+		latest, err := db.Backup(backupDir, 2)
+		assert.NoError(t, err)
+
+		backups, err := ListBackups(backupDir)
+		assert.NoError(t, err)
+		assert.Len(t, backups, 2)
+		assert.NotContains(t, backups, path)
+		assert.Equal(t, latest, backups[1])
+	})
+
+	t.Run("restore replaces the data", func(t *testing.T) {
+		backups, err := ListBackups(backupDir)
+		assert.NoError(t, err)
+
+		assert.NoError(t, db.SetByKey([]byte("key-new"), []byte("value-new")))
+		assert.NoError(t, db.DeleteByKey([]byte("key-0")))
+
+		assert.NoError(t, db.Restore(backups[1]))
+		_, err = db.GetByKey([]byte("key-new"))
+		assert.Error(t, err)
+		v, err := db.GetByKey([]byte("key-0"))
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("value-0"), v)
+
+		// the restored database is swapped in without leftovers and is still locked
+		leftovers, err := filepath.Glob(dbName + ".*")
+		assert.NoError(t, err)
+		assert.Empty(t, leftovers)
+		_, err = NewLvlDB(dbName, false)
+		assert.Error(t, err)
+	})
+}
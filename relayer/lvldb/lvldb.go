@@ -12,7 +12,8 @@ import (
 )
 
 type LVLDB struct {
-	db *leveldb.DB
+	db   *leveldb.DB
+	path string
 	sync.Mutex
 }
 
@@ -26,7 +27,7 @@ func NewLvlDB(path string, readonly bool) (*LVLDB, error) {
 
 		return nil, errors.Wrap(err, "levelDB.OpenFile fail: database might be used by other instance: please check")
 	}
-	return &LVLDB{db: ldb}, nil
+	return &LVLDB{db: ldb, path: path}, nil
 }
 
 func (db *LVLDB) GetByKey(key []byte) ([]byte, error) {
@@ -45,6 +46,13 @@ func (db *LVLDB) DeleteByKey(key []byte) error {
 	return db.db.Delete(key, nil)
 }
 
+// WriteBatch writes all the changes of batch at once
+func (db *LVLDB) WriteBatch(batch *leveldb.Batch) error {
+	db.Lock()
+	defer db.Unlock()
+	return db.db.Write(batch, nil)
+}
+
 func (db *LVLDB) NewIterator(prefix []byte) iterator.Iterator {
 	return db.db.NewIterator(util.BytesPrefix(prefix), nil)
 }
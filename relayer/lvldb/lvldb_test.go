@@ -0,0 +1,21 @@
+package lvldb
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/icon-project/centralized-relay/relayer/store"
+	"github.com/icon-project/centralized-relay/relayer/store/storetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLvlDB(t *testing.T) {
+	storetest.RunStoreTests(t, func(t *testing.T) store.Store {
+		db, err := NewLvlDB(filepath.Join(t.TempDir(), "db"), false)
+		if err != nil {
+			assert.FailNow(t, "error while creating test db ", err)
+		}
+		t.Cleanup(func() { db.Close() })
+		return db
+	})
+}
@@ -0,0 +1,126 @@
+// Package memdb is an in-memory store.Store for dry runs and tests. The goleveldb memdb it is built on
+// is append-only, the space of overwritten and deleted entries is reclaimed by copying the live
+// entries into a fresh memdb once the dead ones outweigh them. The live data is never written to disk
+package memdb
+
+import (
+	"sync"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/comparer"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/memdb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+const defaultCapacity = 4 * 1024 * 1024
+
+// compactMinGarbage is the size of the dead entries below which the memdb is not compacted
+const compactMinGarbage = defaultCapacity
+
+// MemDB is an in-memory store with the same ordering and prefix iteration as LVLDB,
+// data is lost once the process exits
+type MemDB struct {
+	// mu guards db, which is replaced on compaction, and garbage
+	mu sync.RWMutex
+	db *memdb.DB
+	// garbage is the size of the overwritten and deleted entries still held by db
+	garbage int
+}
+
+func NewMemDB() *MemDB {
+	return &MemDB{db: memdb.New(comparer.DefaultComparer, defaultCapacity)}
+}
+
+func (db *MemDB) GetByKey(key []byte) ([]byte, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	v, err := db.db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	// value points into the memdb buffer
+	return append([]byte(nil), v...), nil
+}
+
+func (db *MemDB) SetByKey(key []byte, value []byte) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.discard(key)
+	if err := db.db.Put(key, value); err != nil {
+		return err
+	}
+	db.compact()
+	return nil
+}
+
+// DeleteByKey ignores missing keys like leveldb does
+func (db *MemDB) DeleteByKey(key []byte) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.discard(key)
+	if err := db.db.Delete(key); err != nil && err != memdb.ErrNotFound {
+		return err
+	}
+	db.compact()
+	return nil
+}
+
+// discard counts the entry of key as garbage, it stays in the memdb buffer once overwritten or deleted
+func (db *MemDB) discard(key []byte) {
+	if v, err := db.db.Get(key); err == nil {
+		db.garbage += len(key) + len(v)
+	}
+}
+
+// compact copies the live entries into a fresh memdb once the dead ones outweigh them, so the copies
+// cost no more than the writes which made the garbage. Open iterators keep reading the former memdb
+func (db *MemDB) compact() {
+	if db.garbage < compactMinGarbage || db.garbage < db.db.Size() {
+		return
+	}
+	fresh := memdb.New(comparer.DefaultComparer, max(defaultCapacity, db.db.Size()))
+	iter := db.db.NewIterator(nil)
+	defer iter.Release()
+	for iter.Next() {
+		// the writes to memdb only fail when out of memory
+		_ = fresh.Put(iter.Key(), iter.Value())
+	}
+	db.db, db.garbage = fresh, 0
+}
+
+// WriteBatch applies the changes of batch in order, an in-memory store has nothing to recover from a crash
+func (db *MemDB) WriteBatch(batch *leveldb.Batch) error {
+	return batch.Replay(replayer{db})
+}
+
+// replayer applies the changes of a batch, the writes to memdb only fail when out of memory
+type replayer struct {
+	db *MemDB
+}
+
+func (r replayer) Put(key, value []byte) {
+	_ = r.db.SetByKey(key, value)
+}
+
+func (r replayer) Delete(key []byte) {
+	_ = r.db.DeleteByKey(key)
+}
+
+func (db *MemDB) NewIterator(prefix []byte) iterator.Iterator {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	return db.db.NewIterator(util.BytesPrefix(prefix))
+}
+
+func (db *MemDB) ClearStore() error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.db.Reset()
+	db.garbage = 0
+	return nil
+}
+
+func (db *MemDB) Close() error {
+	return nil
+}
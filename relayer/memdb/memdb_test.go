@@ -0,0 +1,50 @@
+package memdb
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/icon-project/centralized-relay/relayer/store"
+	"github.com/icon-project/centralized-relay/relayer/store/storetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemDB(t *testing.T) {
+	t.Parallel()
+	storetest.RunStoreTests(t, func(t *testing.T) store.Store {
+		return NewMemDB()
+	})
+}
+
+func TestMemDBCompact(t *testing.T) {
+	t.Parallel()
+	db := NewMemDB()
+	require.NoError(t, db.SetByKey([]byte("kept"), []byte("value")))
+	iter := db.NewIterator([]byte("kept"))
+	defer iter.Release()
+
+	// the overwritten values are dropped once they outweigh the live entries
+	value := bytes.Repeat([]byte{1}, 64*1024)
+	for i := 0; i < 2*compactMinGarbage/len(value); i++ {
+		value[0] = byte(i)
+		require.NoError(t, db.SetByKey([]byte("overwritten"), value))
+		require.NoError(t, db.SetByKey([]byte("deleted"), value))
+		require.NoError(t, db.DeleteByKey([]byte("deleted")))
+	}
+	assert.Less(t, db.garbage, compactMinGarbage)
+	assert.Equal(t, len("kept")+len("value")+len("overwritten")+len(value), db.db.Size())
+
+	v, err := db.GetByKey([]byte("overwritten"))
+	require.NoError(t, err)
+	assert.Equal(t, value, v)
+	v, err = db.GetByKey([]byte("kept"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), v)
+	_, err = db.GetByKey([]byte("deleted"))
+	assert.Error(t, err)
+
+	// an iterator opened before keeps reading the former entries
+	require.True(t, iter.Next())
+	assert.Equal(t, []byte("value"), iter.Value())
+}
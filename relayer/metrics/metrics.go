@@ -0,0 +1,121 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+)
+
+const namespace = "centralized_relay"
+
+var (
+	registry = prometheus.NewRegistry()
+
+	RPCEndpointLatency = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "rpc_endpoint_latency_seconds",
+		Help:      "Moving average latency of the rpc endpoint.",
+	}, []string{"nid", "url"})
+
+	RPCEndpointErrorRate = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "rpc_endpoint_error_rate",
+		Help:      "Moving average error rate of the rpc endpoint between 0 and 1.",
+	}, []string{"nid", "url"})
+
+	RPCEndpointHeight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "rpc_endpoint_height",
+		Help:      "Latest block height reported by the rpc endpoint.",
+	}, []string{"nid", "url"})
+
+	RPCEndpointHealthy = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "rpc_endpoint_healthy",
+		Help:      "1 if the rpc endpoint is considered healthy.",
+	}, []string{"nid", "url"})
+
+	MessagesQuarantined = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "messages_quarantined_total",
+		Help:      "Number of messages quarantined because they failed verification.",
+	}, []string{"nid"})
+
+	MessagesUnroutable = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "messages_unroutable",
+		Help:      "Number of messages kept until their destination chain or route is relayed.",
+	}, []string{"src", "dst"})
+
+	MessageGaps = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "message_gaps_total",
+		Help:      "Number of messages found by the audit which their destination did not receive.",
+	}, []string{"src", "dst"})
+
+	Leader = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "leader",
+		Help:      "1 if the relayer holds the leadership lease and routes messages, 0 on a standby.",
+	})
+
+	WalletBalance = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "wallet_balance",
+		Help:      "Balance of the relayer wallet in the display unit of the denom.",
+	}, []string{"nid", "address", "denom"})
+
+	WalletBalanceLevel = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "wallet_balance_level",
+		Help:      "Level of the relayer wallet balance, 0 ok, 1 warn and 2 critical.",
+	}, []string{"nid", "address"})
+)
+
+func init() {
+	registry.MustRegister(
+		prometheus.NewGoCollector(),
+		RPCEndpointLatency,
+		RPCEndpointErrorRate,
+		RPCEndpointHeight,
+		RPCEndpointHealthy,
+		MessagesQuarantined,
+		MessagesUnroutable,
+		MessageGaps,
+		Leader,
+		WalletBalance,
+		WalletBalanceLevel,
+	)
+}
+
+// Register adds collectors to the relayer registry
+func Register(cs ...prometheus.Collector) {
+	registry.MustRegister(cs...)
+}
+
+// Handler returns the http handler exposing the relayer metrics
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// Serve exposes the metrics on addr under /metrics until ctx is done
+func Serve(ctx context.Context, log *zap.Logger, addr string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+	srv := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		<-ctx.Done()
+		srv.Close()
+	}()
+
+	log.Info("serving metrics", zap.String("addr", addr))
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Error("metrics server stopped", zap.Error(err))
+	}
+}
@@ -0,0 +1,26 @@
+package relayer
+
+import (
+	"github.com/icon-project/centralized-relay/relayer/store"
+)
+
+// migrations is the ordered registry of database schema migrations,
+// append a new migration whenever the stored format of a message, block or finality object changes
+var migrations = []store.Migration{
+	{
+		Version:     1,
+		Description: "initial schema: message, block and finality stores encoded as json",
+	},
+	{
+		Version:     2,
+		Description: "pause keys escape the dashes of the nids",
+		Up: func(db store.Store) error {
+			return store.NewPauseStore(db, prefixPauseStore).Rekey()
+		},
+	},
+}
+
+// NewMigrator returns a migrator with all the registered relayer migrations
+func NewMigrator(db store.Store) *store.Migrator {
+	return store.NewMigrator(db, migrations)
+}
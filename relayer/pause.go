@@ -0,0 +1,164 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"go.uber.org/zap"
+)
+
+type routeKey struct {
+	src, dst string
+}
+
+func pauseKey(p *types.Pause) routeKey {
+	return routeKey{p.Src, p.Dst}
+}
+
+// loadPauses reads the pauses persisted by a previous run
+func (r *Relayer) loadPauses() error {
+	pauses, err := r.pauseStore.GetPauses()
+	if err != nil {
+		return err
+	}
+	r.pauses = make(map[routeKey]*types.Pause, len(pauses))
+	for _, p := range pauses {
+		r.pauses[pauseKey(p)] = p
+	}
+	return nil
+}
+
+// Pause stops relaying for a chain or a route until it is resumed or the pause expires.
+// The listener of a paused chain is stopped, messages from and to a paused chain or route
+// stay queued and are relayed once resumed
+func (r *Relayer) Pause(p types.Pause) error {
+	if _, err := r.FindChainRuntime(p.Src); err != nil {
+		return err
+	}
+	if p.Dst != "" {
+		if _, err := r.FindChainRuntime(p.Dst); err != nil {
+			return err
+		}
+	}
+	if p.CreatedAt.IsZero() {
+		p.CreatedAt = time.Now().UTC()
+	}
+
+	r.lifecycleMu.Lock()
+	defer r.lifecycleMu.Unlock()
+	if err := r.pauseStore.StorePause(&p); err != nil {
+		return err
+	}
+	r.pausesMu.Lock()
+	r.pauses[pauseKey(&p)] = &p
+	r.pausesMu.Unlock()
+
+	r.log.Warn("relaying paused", zap.String("src", p.Src), zap.String("dst", p.Dst), zap.String("reason", p.Reason))
+	if p.IsChain() {
+		if chainRuntime, err := r.FindChainRuntime(p.Src); err == nil {
+			r.stopChain(chainRuntime)
+		}
+	}
+	return nil
+}
+
+// Resume removes the pause of the chain, or of the route when dst is set
+func (r *Relayer) Resume(src, dst string) error {
+	r.lifecycleMu.Lock()
+	defer r.lifecycleMu.Unlock()
+	return r.resume(routeKey{src, dst})
+}
+
+func (r *Relayer) resume(key routeKey) error {
+	r.pausesMu.Lock()
+	_, ok := r.pauses[key]
+	delete(r.pauses, key)
+	r.pausesMu.Unlock()
+	if !ok {
+		return fmt.Errorf("no pause found for src %q dst %q", key.src, key.dst)
+	}
+	if err := r.pauseStore.DeletePause(key.src, key.dst); err != nil {
+		return err
+	}
+
+	r.log.Info("relaying resumed", zap.String("src", key.src), zap.String("dst", key.dst))
+	if key.dst == "" && r.ctx != nil {
+		if chainRuntime, err := r.FindChainRuntime(key.src); err == nil {
+			r.runChain(r.ctx, chainRuntime)
+		}
+	}
+	return nil
+}
+
+// resumeExpired resumes the pauses which expired
+func (r *Relayer) resumeExpired() {
+	now := time.Now()
+	var expired []routeKey
+	r.pausesMu.RLock()
+	for key, p := range r.pauses {
+		if p.Expired(now) {
+			expired = append(expired, key)
+		}
+	}
+	r.pausesMu.RUnlock()
+	if len(expired) == 0 {
+		return
+	}
+
+	r.lifecycleMu.Lock()
+	defer r.lifecycleMu.Unlock()
+	for _, key := range expired {
+		if err := r.resume(key); err != nil {
+			r.log.Error("failed to resume expired pause", zap.String("src", key.src), zap.String("dst", key.dst), zap.Error(err))
+		}
+	}
+}
+
+// Pauses returns the current pauses sorted by route
+func (r *Relayer) Pauses() []*types.Pause {
+	r.pausesMu.RLock()
+	defer r.pausesMu.RUnlock()
+	pauses := make([]*types.Pause, 0, len(r.pauses))
+	for _, p := range r.pauses {
+		pauses = append(pauses, p)
+	}
+	sort.Slice(pauses, func(i, j int) bool {
+		if pauses[i].Src != pauses[j].Src {
+			return pauses[i].Src < pauses[j].Src
+		}
+		return pauses[i].Dst < pauses[j].Dst
+	})
+	return pauses
+}
+
+// chainPaused reports whether the whole chain is paused
+func (r *Relayer) chainPaused(nId string) bool {
+	r.pausesMu.RLock()
+	defer r.pausesMu.RUnlock()
+	_, ok := r.pauses[routeKey{nId, ""}]
+	return ok
+}
+
+// routePaused reports whether messages from src to dst must be held back
+func (r *Relayer) routePaused(src, dst string) bool {
+	r.pausesMu.RLock()
+	defer r.pausesMu.RUnlock()
+	for _, key := range []routeKey{{src, ""}, {dst, ""}, {src, dst}} {
+		if _, ok := r.pauses[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// startChain runs the chain unless it is paused
+func (r *Relayer) startChain(ctx context.Context, chainRuntime *ChainRuntime) {
+	if r.chainPaused(chainRuntime.Provider.NID()) {
+		r.log.Warn("chain is paused, listener not started", zap.String("nid", chainRuntime.Provider.NID()))
+		return
+	}
+	r.runChain(ctx, chainRuntime)
+}
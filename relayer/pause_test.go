@@ -0,0 +1,71 @@
+package relayer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/memdb"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPauseResume(t *testing.T) {
+	log := zap.NewNop()
+	chains := make(map[string]*Chain)
+	for _, nIds := range [][2]string{{"mock-1", "mock-2"}, {"mock-2", "mock-1"}} {
+		p, err := GetMockChainProvider(log, time.Second, nIds[0], nIds[1], 10, 10)
+		require.NoError(t, err)
+		chains[nIds[0]] = NewChain(log, p, false)
+	}
+	db := memdb.NewMemDB()
+	rly, err := NewRelayer(log, db, chains, true)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	rly.errorChan, rly.ctx = make(chan error, 1), ctx
+	for _, chainRuntime := range rly.chainRuntimes() {
+		rly.startChain(ctx, chainRuntime)
+	}
+	src, err := rly.FindChainRuntime("mock-1")
+	require.NoError(t, err)
+	dst, err := rly.FindChainRuntime("mock-2")
+	require.NoError(t, err)
+
+	// messages of a paused route stay queued
+	require.NoError(t, rly.Pause(types.Pause{Src: "mock-1", Dst: "mock-2", Reason: "upgrade"}))
+	m := types.NewRouteMessage(&types.Message{Src: "mock-1", Dst: "mock-2", Sn: 1})
+	src.MessageCache.Add(m)
+	rly.processMessages(ctx)
+	assert.False(t, m.GetIsProcessing())
+	assert.Equal(t, uint64(1), src.MessageCache.Len())
+	assert.False(t, rly.routePaused("mock-2", "mock-1"))
+
+	// a paused chain stops listening and pauses every route from or to it
+	require.NoError(t, rly.Pause(types.Pause{Src: "mock-2"}))
+	assert.Nil(t, dst.cancel)
+	assert.True(t, rly.routePaused("mock-1", "mock-2"))
+	assert.True(t, rly.routePaused("mock-2", "mock-1"))
+	assert.Error(t, rly.Pause(types.Pause{Src: "unknown"}))
+
+	// pauses are persisted
+	reopened, err := NewRelayer(log, db, chains, false)
+	require.NoError(t, err)
+	assert.Len(t, reopened.Pauses(), 2)
+	assert.True(t, reopened.chainPaused("mock-2"))
+
+	require.NoError(t, rly.Resume("mock-2", ""))
+	assert.NotNil(t, dst.cancel)
+	assert.Error(t, rly.Resume("mock-2", ""))
+
+	// expired pauses are resumed by the router
+	expired := time.Now().Add(-time.Second)
+	require.NoError(t, rly.Pause(types.Pause{Src: "mock-1", Dst: "mock-2", ExpiresAt: &expired}))
+	rly.resumeExpired()
+	assert.Empty(t, rly.Pauses())
+	reopened, err = NewRelayer(log, db, chains, false)
+	require.NoError(t, err)
+	assert.Empty(t, reopened.Pauses())
+}
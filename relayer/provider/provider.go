@@ -12,6 +12,13 @@ var messageMaxRetry = 5
 type ProviderConfig interface {
 	NewProvider(log *zap.Logger, homepath string, debug bool, chainName string) (ChainProvider, error)
 	Validate() error
+	GetNID() string
+}
+
+// KeystoreValidator is a config which keystores are checked by config validate, decrypting
+// a keystore is slow so providers only do it once in Init
+type KeystoreValidator interface {
+	ValidateKeystores() error
 }
 
 type ChainQuery interface {
@@ -35,4 +42,26 @@ type ChainProvider interface {
 	FinalityBlock(ctx context.Context) uint64
 	GenerateMessage(ctx context.Context, messageKey *types.MessageKeyWithMessageHeight) (*types.Message, error)
 	QueryBalance(ctx context.Context, addr string) (*types.Coin, error)
+	// GetWalletAddress returns the address of the relayer wallet
+	GetWalletAddress() (string, error)
+}
+
+// SnQuerier is implemented by the providers whose connection contract exposes its sn,
+// the sn of a message emitted by the connection is one more than the sn before it
+type SnQuerier interface {
+	// QueryConnSn returns the sn of the connection after the transactions of the block at height,
+	// at the latest height when height is 0
+	QueryConnSn(ctx context.Context, height uint64) (uint64, error)
+}
+
+// Rescanner is implemented by the providers which can parse the messages of past blocks
+type Rescanner interface {
+	// Rescan returns the blocks from..to which emitted messages, the listener and its checkpoint are not affected
+	Rescan(ctx context.Context, from, to uint64) ([]types.BlockInfo, error)
+}
+
+// TxMessageFinder is implemented by the providers which can parse the messages emitted by a transaction
+type TxMessageFinder interface {
+	// TxMessages returns the messages emitted by the connection in the transaction txHash
+	TxMessages(ctx context.Context, txHash string) ([]*types.Message, error)
 }
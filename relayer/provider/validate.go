@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// ConfigErrors collects every problem found while validating a config
+type ConfigErrors []error
+
+func (e *ConfigErrors) Add(format string, args ...any) {
+	*e = append(*e, fmt.Errorf(format, args...))
+}
+
+// AddErr adds err if it is not nil
+func (e *ConfigErrors) AddErr(err error) {
+	if err != nil {
+		*e = append(*e, err)
+	}
+}
+
+// Err returns nil when no problem was found, the joined problems otherwise
+func (e ConfigErrors) Err() error {
+	return errors.Join(e...)
+}
+
+// ValidateURL checks that rawURL is an absolute url with one of the given schemes
+func ValidateURL(name, rawURL string, schemes ...string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("%s %q is not a valid url: %w", name, rawURL, err)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%s %q has no host", name, rawURL)
+	}
+	for _, scheme := range schemes {
+		if u.Scheme == scheme {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s %q must use one of the schemes %s", name, rawURL, strings.Join(schemes, ", "))
+}
+
+// Endpoints returns rpcURL followed by the additional rpcURLs, without empty urls and duplicates
+func Endpoints(rpcURL string, rpcURLs []string) []string {
+	var urls []string
+	seen := make(map[string]bool)
+	for _, u := range append([]string{rpcURL}, rpcURLs...) {
+		if u == "" || seen[u] {
+			continue
+		}
+		seen[u] = true
+		urls = append(urls, u)
+	}
+	return urls
+}
+
+// ValidateEndpoints checks that there is at least one endpoint and that every endpoint is
+// an absolute url with one of the given schemes, name is the config field of the first one
+func ValidateEndpoints(name string, urls []string, schemes ...string) error {
+	if len(urls) == 0 {
+		return fmt.Errorf("%s is required", name)
+	}
+	var errs ConfigErrors
+	for _, u := range urls {
+		errs.AddErr(ValidateURL(name, u, schemes...))
+	}
+	return errs.Err()
+}
+
+// ReadKeystore reads the keystore file, path is the name of the config field
+func ReadKeystore(name, path string) ([]byte, error) {
+	if path == "" {
+		return nil, fmt.Errorf("%s is required", name)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,20 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEndpoints(t *testing.T) {
+	urls := Endpoints("https://a", []string{"https://b", "", "https://a", "https://b", "https://c"})
+	assert.Equal(t, []string{"https://a", "https://b", "https://c"}, urls)
+	assert.Equal(t, []string{"https://b"}, Endpoints("", []string{"https://b"}))
+	assert.Empty(t, Endpoints("", nil))
+
+	assert.NoError(t, ValidateEndpoints("rpc-url", urls, "http", "https"))
+	assert.EqualError(t, ValidateEndpoints("rpc-url", nil, "http", "https"), "rpc-url is required")
+	err := ValidateEndpoints("rpc-url", []string{"ftp://a", "https://b", "https://"}, "http", "https")
+	assert.ErrorContains(t, err, `"ftp://a" must use one of the schemes http, https`)
+	assert.ErrorContains(t, err, `"https://" has no host`)
+}
@@ -0,0 +1,88 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/icon-project/centralized-relay/relayer/provider"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"go.uber.org/zap"
+)
+
+// ReleaseOptions selects a quarantined message
+type ReleaseOptions struct {
+	Chain string `json:"chain"`
+	Sn    uint64 `json:"sn"`
+}
+
+// ReleaseQuarantined verifies a quarantined message again by rescanning its block, a message passing
+// the verification leaves the quarantine and is queued like an injected one
+func (r *Relayer) ReleaseQuarantined(ctx context.Context, opts ReleaseOptions) (*InjectedMessage, error) {
+	src, err := r.FindChainRuntime(opts.Chain)
+	if err != nil {
+		return nil, err
+	}
+	key := types.MessageKey{Src: opts.Chain, Sn: opts.Sn}
+	quarantined, err := r.quarantineStore.GetMessage(key)
+	if err != nil {
+		return nil, fmt.Errorf("sn %d of %s is not quarantined: %w", opts.Sn, opts.Chain, err)
+	}
+	rescanner, ok := src.Provider.(provider.Rescanner)
+	if !ok {
+		return nil, fmt.Errorf("chain type %s cannot verify messages again", src.Provider.Type())
+	}
+	height := quarantined.MessageHeight
+	blocks, err := rescanner.Rescan(ctx, height, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rescan block %d: %w", height, err)
+	}
+	var m *types.Message
+	for _, block := range blocks {
+		for _, message := range block.Messages {
+			if message.Sn == opts.Sn {
+				m = message
+			}
+		}
+	}
+	if m == nil {
+		return nil, fmt.Errorf("sn %d fails the verification of block %d again", opts.Sn, height)
+	}
+
+	result := &InjectedMessage{Src: m.Src, Dst: m.Dst, Sn: m.Sn, Height: m.MessageHeight}
+	pending, err := r.pendingSn(src)
+	if err != nil {
+		return nil, err
+	}
+	switch reason := r.unroutableReason(m.Src, m.Dst); {
+	case pending[m.Sn]:
+		result.Status = InjectPending
+	case reason != "":
+		r.parkUnroutable(types.NewRouteMessage(m), src, reason)
+		result.Status = InjectUnroutable
+	default:
+		dst, err := r.FindChainRuntime(m.Dst)
+		if err != nil {
+			return nil, err
+		}
+		received, err := dst.Provider.MessageReceived(ctx, m.MessageKey())
+		if err != nil {
+			return nil, fmt.Errorf("receipt of sn %d on %s: %w", m.Sn, m.Dst, err)
+		}
+		result.Status = InjectReceived
+		if !received {
+			if err := r.queueMessage(src, m); err != nil {
+				return nil, fmt.Errorf("failed to queue sn %d: %w", m.Sn, err)
+			}
+			result.Status = InjectQueued
+		}
+	}
+	r.unquarantine(key)
+	return result, nil
+}
+
+// unquarantine drops the quarantine entry of a message which passed the verification
+func (r *Relayer) unquarantine(key types.MessageKey) {
+	if err := r.quarantineStore.DeleteMessage(key); err != nil {
+		r.log.Error("failed to delete quarantined message", zap.String("src", key.Src), zap.Uint64("sn", key.Sn), zap.Error(err))
+	}
+}
@@ -0,0 +1,85 @@
+package relayer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/memdb"
+	"github.com/icon-project/centralized-relay/relayer/store"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestProcessBlockInfoQuarantine(t *testing.T) {
+	t.Parallel()
+	log := zap.NewNop()
+	mockProvider, err := GetMockChainProvider(log, time.Second, "mock-1", "mock-2", 10, 10)
+	require.NoError(t, err)
+	rly, err := NewRelayer(log, memdb.NewMemDB(), map[string]*Chain{"mock-1": NewChain(log, mockProvider, true)}, true)
+	require.NoError(t, err)
+	runtime, err := rly.FindChainRuntime("mock-1")
+	require.NoError(t, err)
+
+	good := &types.Message{Src: "mock-1", Dst: "mock-2", Sn: 1, MessageHeight: 11, EventType: "emitMessage"}
+	bad := &types.Message{Src: "mock-1", Dst: "mock-2", Sn: 2, MessageHeight: 11, EventType: "emitMessage"}
+	rly.processBlockInfo(context.Background(), runtime, types.BlockInfo{
+		Height:      11,
+		Messages:    []*types.Message{good},
+		Quarantined: []*types.Message{bad},
+	})
+
+	quarantined, err := rly.GetQuarantineStore().GetMessages("mock-1", store.NewPagination().GetAll())
+	require.NoError(t, err)
+	require.Len(t, quarantined, 1)
+	assert.Equal(t, bad, quarantined[0].Message)
+
+	assert.Eventually(t, func() bool {
+		runtime.MessageCache.Lock()
+		defer runtime.MessageCache.Unlock()
+		_, ok := runtime.MessageCache.Messages[good.MessageKey()]
+		return ok
+	}, time.Second, 10*time.Millisecond)
+	assert.NotContains(t, runtime.MessageCache.Messages, bad.MessageKey())
+}
+
+func TestReleaseQuarantined(t *testing.T) {
+	log := zap.NewNop()
+	src := newSnProvider(t, log, "mock-1", "mock-2", 50)
+	dst := newSnProvider(t, log, "mock-2", "mock-1", 50)
+	src.emit("mock-2", 10) // 1 verified now
+	src.emit("mock-2", 20) // 2 verified by a rescan
+	forged := &types.Message{Src: "mock-1", Dst: "mock-2", Sn: 3, MessageHeight: 20, EventType: "emitMessage"}
+
+	rly, err := NewRelayer(log, memdb.NewMemDB(), map[string]*Chain{
+		"mock-1": NewChain(log, src, true),
+		"mock-2": NewChain(log, dst, true),
+	}, true)
+	require.NoError(t, err)
+	rly.quarantineMessages(append(src.messages, forged))
+	ctx := context.Background()
+
+	released, err := rly.ReleaseQuarantined(ctx, ReleaseOptions{Chain: "mock-1", Sn: 1})
+	require.NoError(t, err)
+	assert.Equal(t, InjectQueued, released.Status)
+	_, err = rly.quarantineStore.GetMessage(src.messages[0].MessageKey())
+	assert.Error(t, err)
+	_, err = rly.messageStore.GetMessage(src.messages[0].MessageKey())
+	assert.NoError(t, err)
+
+	// a message failing the verification again stays quarantined
+	_, err = rly.ReleaseQuarantined(ctx, ReleaseOptions{Chain: "mock-1", Sn: 3})
+	assert.ErrorContains(t, err, "fails the verification")
+	_, err = rly.quarantineStore.GetMessage(forged.MessageKey())
+	assert.NoError(t, err)
+	_, err = rly.ReleaseQuarantined(ctx, ReleaseOptions{Chain: "mock-1", Sn: 1})
+	assert.ErrorContains(t, err, "not quarantined")
+
+	// a rescan queueing a quarantined message releases it
+	_, err = rly.Rescan(ctx, RescanOptions{Chain: "mock-1", From: 20, To: 20})
+	require.NoError(t, err)
+	_, err = rly.quarantineStore.GetMessage(src.messages[1].MessageKey())
+	assert.Error(t, err)
+}
@@ -3,8 +3,11 @@ package relayer
 import (
 	"context"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/icon-project/centralized-relay/relayer/metrics"
 	"github.com/icon-project/centralized-relay/relayer/store"
 	"github.com/icon-project/centralized-relay/relayer/types"
 	"go.uber.org/zap"
@@ -23,8 +26,21 @@ var (
 	prefixMessageStore  = "message"
 	prefixBlockStore    = "block"
 	prefixFinalityStore = "finality"
+	// prefixQuarantineStore holds messages which failed verification
+	prefixQuarantineStore = "quarantine"
+	// prefixPauseStore holds the pauses of chains and routes
+	prefixPauseStore = "pause"
+	// prefixAuditStore holds the last audited sn of every source chain
+	prefixAuditStore = "audit"
+	// prefixAuditAttemptsStore holds the number of audits which did not move the checkpoint of a source chain
+	prefixAuditAttemptsStore = "auditattempts"
+	// prefixUnroutableStore holds the messages to chains or routes this relayer does not relay
+	prefixUnroutableStore = "unroutable"
 )
 
+// Option starts an optional service of the relayer
+type Option func(ctx context.Context, r *Relayer)
+
 // main start loop
 func Start(
 	ctx context.Context,
@@ -33,12 +49,14 @@ func Start(
 	flushInterval time.Duration,
 	fresh bool,
 	db store.Store,
+	opts ...Option,
 ) (chan error, error) {
 	errorChan := make(chan error, 1)
 	relayer, err := NewRelayer(log, db, chains, fresh)
 	if err != nil {
 		return nil, fmt.Errorf("error creating new relayer %v", err)
 	}
+	relayer.flushInterval = flushInterval
 
 	// once flush completes then only start processing
 	if !fresh {
@@ -46,15 +64,22 @@ func Start(
 		relayer.flushMessages(ctx)
 	}
 
-	// // create ctx -> with cancel function and senc cancel function to all -> ctx.done():
-	// // start all the chain listeners
-	go relayer.StartChainListeners(ctx, errorChan)
-
-	// // start all the block processor
-	go relayer.StartBlockProcessors(ctx, errorChan)
+	// start the listener and the block processor of every chain,
+	// chains can be started and stopped later on with Reload
+	relayer.errorChan = errorChan
+	relayer.ctx = ctx
+	// options are applied before the router runs, they may change what it relays
+	for _, opt := range opts {
+		opt(ctx, relayer)
+	}
+	// messages parked for a chain or a route relayed now are picked up by the router
+	relayer.requeueUnroutable()
+	for _, chainRuntime := range relayer.chainRuntimes() {
+		relayer.startChain(ctx, chainRuntime)
+	}
 
 	// responsible to relaying  messages
-	go relayer.StartRouter(ctx, flushInterval)
+	go relayer.StartRouter(ctx, relayer.flushTick())
 
 	// responsible for checking finality
 	go relayer.StartFinalityProcessor(ctx)
@@ -63,11 +88,44 @@ func Start(
 }
 
 type Relayer struct {
-	log           *zap.Logger
-	chains        map[string]*ChainRuntime
+	log *zap.Logger
+	db  store.Store
+	// chainsMu guards chains, which change when the chain config is reloaded
+	chainsMu  sync.RWMutex
+	chains    map[string]*ChainRuntime
+	errorChan chan error
+	// ctx is the context the relayer was started with, resumed chains run until it is done
+	ctx context.Context
+	// lifecycleMu serializes starting and stopping chains on reload, pause and resume
+	lifecycleMu   sync.Mutex
+	pausesMu      sync.RWMutex
+	pauses        map[routeKey]*types.Pause
+	pauseStore    *store.PauseStore
 	messageStore  *store.MessageStore
 	blockStore    *store.BlockStore
 	finalityStore *store.FinalityStore
+	// quarantineStore holds messages which failed verification
+	quarantineStore *store.MessageStore
+	// auditStore holds the last audited sn of every source chain
+	auditStore *store.BlockStore
+	// auditAttemptsStore holds the number of audits the checkpoint of every source chain did not move
+	auditAttemptsStore *store.BlockStore
+	// routes are the routes relayed by this relayer, all the routes when nil
+	routes map[routeKey]bool
+	// policies override the defaults of the relayer for the messages of a route
+	policies map[routeKey]Route
+	// flushInterval is how often the stored messages of the routes without a policy are flushed
+	flushInterval time.Duration
+	// flushMu guards lastFlush, which is when the stored messages of every route were flushed last
+	flushMu   sync.Mutex
+	lastFlush map[routeKey]time.Time
+	// unroutableStore holds the messages parked until their destination is relayed
+	unroutableStore *store.MessageStore
+	// standby is set while another relayer holds the leadership lease, a standby
+	// keeps listening but does not route messages
+	standby atomic.Bool
+	// leaseDeadline is when the lease held by a leader may expire without a renewal
+	leaseDeadline atomic.Pointer[time.Time]
 }
 
 func NewRelayer(log *zap.Logger, db store.Store, chains map[string]*Chain, fresh bool) (*Relayer, error) {
@@ -79,6 +137,15 @@ func NewRelayer(log *zap.Logger, db store.Store, chains map[string]*Chain, fresh
 		}
 	}
 
+	// upgrade the stored data to the latest schema before using it
+	applied, err := NewMigrator(db).Migrate()
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
+	for _, m := range applied {
+		log.Info("applied database migration", zap.Uint64("version", m.Version), zap.String("description", m.Description))
+	}
+
 	// initializing message store
 	messageStore := store.NewMessageStore(db, prefixMessageStore)
 
@@ -88,6 +155,9 @@ func NewRelayer(log *zap.Logger, db store.Store, chains map[string]*Chain, fresh
 	// finality store
 	finalityStore := store.NewFinalityStore(db, prefixFinalityStore)
 
+	// quarantine store
+	quarantineStore := store.NewMessageStore(db, prefixQuarantineStore)
+
 	chainRuntimes := make(map[string]*ChainRuntime, len(chains))
 	for _, chain := range chains {
 		chainRuntime, err := NewChainRuntime(log, chain)
@@ -104,13 +174,25 @@ func NewRelayer(log *zap.Logger, db store.Store, chains map[string]*Chain, fresh
 
 	}
 
-	return &Relayer{
-		log:           log,
-		chains:        chainRuntimes,
-		messageStore:  messageStore,
-		blockStore:    blockStore,
-		finalityStore: finalityStore,
-	}, nil
+	r := &Relayer{
+		log:                log,
+		db:                 db,
+		chains:             chainRuntimes,
+		messageStore:       messageStore,
+		blockStore:         blockStore,
+		finalityStore:      finalityStore,
+		quarantineStore:    quarantineStore,
+		pauseStore:         store.NewPauseStore(db, prefixPauseStore),
+		auditStore:         store.NewBlockStore(db, prefixAuditStore),
+		auditAttemptsStore: store.NewBlockStore(db, prefixAuditAttemptsStore),
+		unroutableStore:    store.NewMessageStore(db, prefixUnroutableStore),
+		flushInterval:      DefaultFlushInterval,
+		lastFlush:          make(map[routeKey]time.Time),
+	}
+	if err := r.loadPauses(); err != nil {
+		return nil, fmt.Errorf("failed to load pauses: %w", err)
+	}
+	return r, nil
 }
 
 // GetBlockStore returns the block store
@@ -123,19 +205,27 @@ func (r *Relayer) GetMessageStore() *store.MessageStore {
 	return r.messageStore
 }
 
+// GetQuarantineStore returns the store of messages which failed verification
+func (r *Relayer) GetQuarantineStore() *store.MessageStore {
+	return r.quarantineStore
+}
+
+// GetUnroutableStore returns the store of messages parked until their destination is relayed
+func (r *Relayer) GetUnroutableStore() *store.MessageStore {
+	return r.unroutableStore
+}
+
 func (r *Relayer) StartChainListeners(
 	ctx context.Context,
 	errCh chan error,
 ) {
 	var eg errgroup.Group
 
-	for _, chainRuntime := range r.chains {
+	for _, chainRuntime := range r.chainRuntimes() {
 		chainRuntime := chainRuntime
 
 		eg.Go(func() error {
-			// listening to the block
-			err := chainRuntime.Provider.Listener(ctx, chainRuntime.LastSavedHeight, chainRuntime.listenerChan)
-			return err
+			return r.startChainListener(ctx, chainRuntime)
 		})
 	}
 	if err := eg.Wait(); err != nil {
@@ -143,24 +233,18 @@ func (r *Relayer) StartChainListeners(
 	}
 }
 
+func (r *Relayer) startChainListener(ctx context.Context, chainRuntime *ChainRuntime) error {
+	// listening to the block
+	return chainRuntime.Provider.Listener(ctx, chainRuntime.LastSavedHeight, chainRuntime.listenerChan)
+}
+
 func (r *Relayer) StartBlockProcessors(ctx context.Context, errorChan chan error) {
 	var eg errgroup.Group
 
-	for _, chainRuntime := range r.chains {
+	for _, chainRuntime := range r.chainRuntimes() {
 		chainRuntime := chainRuntime
-		listener := chainRuntime.listenerChan
 		eg.Go(func() error {
-			for {
-				select {
-				case <-ctx.Done():
-					return ctx.Err()
-				case blockInfo, ok := <-listener:
-					if !ok {
-						return nil
-					}
-					r.processBlockInfo(ctx, chainRuntime, blockInfo)
-				}
-			}
+			return r.startBlockProcessor(ctx, chainRuntime)
 		})
 	}
 
@@ -169,6 +253,21 @@ func (r *Relayer) StartBlockProcessors(ctx context.Context, errorChan chan error
 	}
 }
 
+func (r *Relayer) startBlockProcessor(ctx context.Context, chainRuntime *ChainRuntime) error {
+	listener := chainRuntime.listenerChan
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case blockInfo, ok := <-listener:
+			if !ok {
+				return nil
+			}
+			r.processBlockInfo(ctx, chainRuntime, blockInfo)
+		}
+	}
+}
+
 func (r *Relayer) StartRouter(ctx context.Context, flushInterval time.Duration) {
 	routeTimer := time.NewTicker(RouteDuration)
 	flushTimer := time.NewTicker(flushInterval)
@@ -186,6 +285,8 @@ func (r *Relayer) StartRouter(ctx context.Context, flushInterval time.Duration)
 }
 
 func (r *Relayer) flushMessages(ctx context.Context) {
+	r.flushMu.Lock()
+	defer r.flushMu.Unlock()
 	r.log.Info("starting flush logic by adding messages to the messageCache")
 
 	count, err := r.messageStore.TotalCount()
@@ -197,7 +298,7 @@ func (r *Relayer) flushMessages(ctx context.Context) {
 		return
 	}
 
-	for _, chain := range r.chains {
+	for _, chain := range r.chainRuntimes() {
 		nId := chain.Provider.NID()
 		messages, err := r.getActiveMessagesFromStore(nId, maxFlushMessage)
 		if err != nil {
@@ -212,9 +313,19 @@ func (r *Relayer) flushMessages(ctx context.Context) {
 		// adding message to messageCache
 		// TODO: message with no txHash
 
+		now := time.Now()
+		flushed := make(map[routeKey]bool)
 		for _, m := range messages {
+			key := routeKey{m.Src, m.Dst}
+			if !r.flushDue(key, now) {
+				continue
+			}
+			flushed[key] = true
 			chain.MessageCache.Add(m)
 		}
+		for key := range flushed {
+			r.lastFlush[key] = now
+		}
 	}
 }
 
@@ -239,13 +350,31 @@ func (r *Relayer) getActiveMessagesFromStore(nId string, maxMessages int) ([]*ty
 }
 
 func (r *Relayer) processMessages(ctx context.Context) {
-	for _, srcChainRuntime := range r.chains {
-		for _, routeMessage := range srcChainRuntime.MessageCache.Messages {
+	if !r.leading() {
+		return
+	}
+	r.resumeExpired()
+	for _, srcChainRuntime := range r.chainRuntimes() {
+		for _, routeMessage := range srcChainRuntime.MessageCache.Snapshot() {
 			dstChainRuntime, err := r.FindChainRuntime(routeMessage.Dst)
 			if err != nil {
-				r.log.Error("dst chain runtime not found ", zap.String("dst chain", routeMessage.Dst))
-				// remove message if src runtime if dst not found
-				r.ClearMessages(ctx, []types.MessageKey{routeMessage.MessageKey()}, srcChainRuntime)
+				// the chain may be configured later on, the message is relayed then
+				if !routeMessage.GetIsProcessing() {
+					r.parkUnroutable(routeMessage, srcChainRuntime, "destination chain is not configured")
+				}
+				continue
+			}
+
+			// messages of the routes relayed by another relayer are kept in the db
+			if !r.routeActive(routeMessage.Src, routeMessage.Dst) {
+				if !routeMessage.GetIsProcessing() {
+					r.parkUnroutable(routeMessage, srcChainRuntime, "route is not relayed by this relayer")
+				}
+				continue
+			}
+
+			// paused messages stay in the cache until the route is resumed
+			if dstChainRuntime.routingPaused() || r.routePaused(routeMessage.Src, routeMessage.Dst) {
 				continue
 			}
 
@@ -280,9 +409,21 @@ func (r *Relayer) processBlockInfo(ctx context.Context, srcChainRuntime *ChainRu
 		r.log.Error("unable to save height", zap.Error(err))
 	}
 
+	r.quarantineMessages(blockInfo.Quarantined)
+
 	go srcChainRuntime.mergeMessages(ctx, blockInfo.Messages)
 }
 
+// quarantineMessages keeps the messages which failed verification out of the relay path
+func (r *Relayer) quarantineMessages(messages []*types.Message) {
+	for _, m := range messages {
+		metrics.MessagesQuarantined.WithLabelValues(m.Src).Inc()
+		if err := r.quarantineStore.StoreMessage(types.NewRouteMessage(m)); err != nil {
+			r.log.Error("failed to store quarantined message", zap.String("src", m.Src), zap.Uint64("sn", m.Sn), zap.Error(err))
+		}
+	}
+}
+
 func (r *Relayer) SaveBlockHeight(ctx context.Context, chainRuntime *ChainRuntime, height uint64, messageCount int) error {
 
 	if messageCount > 0 || (height-chainRuntime.LastSavedHeight) > uint64(SaveHeightMaxAfter) {
@@ -297,6 +438,8 @@ func (r *Relayer) SaveBlockHeight(ctx context.Context, chainRuntime *ChainRuntim
 }
 
 func (r *Relayer) FindChainRuntime(nId string) (*ChainRuntime, error) {
+	r.chainsMu.RLock()
+	defer r.chainsMu.RUnlock()
 	if chainRuntime, ok := r.chains[nId]; ok {
 		return chainRuntime, nil
 	}
@@ -363,7 +506,7 @@ func (r *Relayer) RouteMessage(ctx context.Context, m *types.RouteMessage, dst,
 func (r *Relayer) HandleMessageFailed(routeMessage *types.RouteMessage, dst, src *ChainRuntime) {
 	routeMessage.SetIsProcessing(false)
 
-	if routeMessage.GetRetry() != 0 && routeMessage.GetRetry()%uint64(types.DefaultTxRetry) == 0 {
+	if routeMessage.GetRetry() != 0 && routeMessage.GetRetry()%r.txRetry(routeMessage.Src, routeMessage.Dst) == 0 {
 		// save to db
 		if err := r.messageStore.StoreMessage(routeMessage); err != nil {
 			r.log.Error("error occured when storing the message after max retry", zap.Error(err))
@@ -401,7 +544,10 @@ func (r *Relayer) StartFinalityProcessor(ctx context.Context) {
 	for {
 		select {
 		case <-ticker.C:
-			r.CheckFinality(ctx)
+			// the transactions of a former leader are checked once it leads again
+			if r.leading() {
+				r.CheckFinality(ctx)
+			}
 		}
 	}
 
@@ -409,7 +555,7 @@ func (r *Relayer) StartFinalityProcessor(ctx context.Context) {
 
 func (r *Relayer) CheckFinality(ctx context.Context) {
 
-	for _, c := range r.chains {
+	for _, c := range r.chainRuntimes() {
 		// check for the finality only if finalityblock is provided by the chain
 		finalityBlock := c.Provider.FinalityBlock(ctx)
 		latestHeight := c.LastBlockHeight
@@ -465,8 +611,8 @@ func (r *Relayer) CheckFinality(ctx context.Context) {
 					zap.String("tx hash on destination chain", txObject.TxHash))
 
 				// if receipt donot exist generate message again and send to src chain
-				srcChainRuntime, ok := r.chains[txObject.Src]
-				if !ok {
+				srcChainRuntime, err := r.FindChainRuntime(txObject.Src)
+				if err != nil {
 					r.log.Error("finality processor:  ",
 						zap.Any("message key", txObject.MessageKey),
 						zap.Error(err))
@@ -7,22 +7,18 @@ import (
 	"time"
 
 	"github.com/icon-project/centralized-relay/relayer/chains/mockchain"
-	"github.com/icon-project/centralized-relay/relayer/lvldb"
+	"github.com/icon-project/centralized-relay/relayer/memdb"
 	"github.com/icon-project/centralized-relay/relayer/provider"
 	"github.com/icon-project/centralized-relay/relayer/types"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/zap"
 )
 
-const (
-	levelDbName = "./testdb"
-)
-
 type RelayTestSuite struct {
 	suite.Suite
 
 	logger *zap.Logger
-	db     *lvldb.LVLDB
+	db     *memdb.MemDB
 	relay  Relayer
 }
 
@@ -79,12 +75,7 @@ func GetMockChainProvider(log *zap.Logger, blockDuration time.Duration, NId stri
 
 func (s *RelayTestSuite) SetupTest() {
 	logger, _ := zap.NewProduction()
-	db, err := lvldb.NewLvlDB(levelDbName, false)
-	if err != nil {
-		s.Fail("fail to create leveldb", err)
-	}
-
-	s.db = db
+	s.db = memdb.NewMemDB()
 	s.logger = logger
 }
 
@@ -138,11 +129,6 @@ loop:
 
 		}
 	}
-
-	s.T().Cleanup(func() {
-		s.db.Close()
-		s.db.RemoveDbFile(levelDbName)
-	})
 }
 
 func (s *RelayTestSuite) TestRelay() {
@@ -196,8 +182,4 @@ loop:
 			return
 		}
 	}
-	s.T().Cleanup(func() {
-		s.db.Close()
-		s.db.RemoveDbFile(levelDbName)
-	})
 }
@@ -0,0 +1,206 @@
+package relayer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sort"
+
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"go.uber.org/zap"
+)
+
+// ReloadResult lists the nids of the chains changed by a reload
+type ReloadResult struct {
+	Added     []string `json:"added"`
+	Removed   []string `json:"removed"`
+	Restarted []string `json:"restarted"`
+}
+
+// Changed is true when the reload started or stopped a chain
+func (r ReloadResult) Changed() bool {
+	return len(r.Added)+len(r.Removed)+len(r.Restarted) > 0
+}
+
+// chainRuntimes returns a snapshot of the running chains
+func (r *Relayer) chainRuntimes() []*ChainRuntime {
+	r.chainsMu.RLock()
+	defer r.chainsMu.RUnlock()
+	runtimes := make([]*ChainRuntime, 0, len(r.chains))
+	for _, chainRuntime := range r.chains {
+		runtimes = append(runtimes, chainRuntime)
+	}
+	return runtimes
+}
+
+// runChain starts the listener and the block processor of the chain until ctx is done or the chain is stopped
+func (r *Relayer) runChain(ctx context.Context, chainRuntime *ChainRuntime) {
+	if chainRuntime.cancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	chainRuntime.cancel = cancel
+
+	chainRuntime.wg.Add(2)
+	go func() {
+		defer chainRuntime.wg.Done()
+		if err := r.startChainListener(ctx, chainRuntime); err != nil && ctx.Err() == nil {
+			r.reportError(err)
+		}
+	}()
+	go func() {
+		defer chainRuntime.wg.Done()
+		if err := r.startBlockProcessor(ctx, chainRuntime); err != nil && !errors.Is(err, context.Canceled) {
+			r.reportError(err)
+		}
+	}()
+}
+
+// stopChain stops the listener and the block processor of the chain and waits for them to return,
+// blocks left unprocessed are dropped as the listener starts again from the last saved height
+func (r *Relayer) stopChain(chainRuntime *ChainRuntime) {
+	if chainRuntime.cancel == nil {
+		return
+	}
+	chainRuntime.cancel()
+	chainRuntime.wg.Wait()
+	chainRuntime.cancel = nil
+	for {
+		select {
+		case <-chainRuntime.listenerChan:
+		default:
+			return
+		}
+	}
+}
+
+// closeProvider releases the key held by the provider of the chain
+func (r *Relayer) closeProvider(chainRuntime *ChainRuntime) {
+	closer, ok := chainRuntime.Provider.(io.Closer)
+	if !ok {
+		return
+	}
+	if err := closer.Close(); err != nil {
+		r.log.Warn("failed to close provider", zap.String("nid", chainRuntime.Provider.NID()), zap.Error(err))
+	}
+}
+
+// WithKeyZeroing zeroes the keys held in memory by the providers once the relayer stops
+func WithKeyZeroing() Option {
+	return func(ctx context.Context, r *Relayer) {
+		go func() {
+			<-ctx.Done()
+			r.lifecycleMu.Lock()
+			defer r.lifecycleMu.Unlock()
+			for _, chainRuntime := range r.chainRuntimes() {
+				r.stopChain(chainRuntime)
+				r.closeProvider(chainRuntime)
+			}
+			r.log.Info("provider keys zeroed")
+		}()
+	}
+}
+
+func (r *Relayer) reportError(err error) {
+	select {
+	case r.errorChan <- err:
+	default:
+		r.log.Error("relayer error", zap.Error(err))
+	}
+}
+
+// Reload applies the chain set to the running relayer, chains are identified by nid.
+// Chains not in the set are stopped and the messages they have yet to relay are kept in the db,
+// chains with a new provider are restarted with their message cache and new chains are started.
+// Chains with the same provider keep running untouched
+func (r *Relayer) Reload(ctx context.Context, chains map[string]*Chain) (ReloadResult, error) {
+	r.lifecycleMu.Lock()
+	defer r.lifecycleMu.Unlock()
+
+	result := ReloadResult{Added: []string{}, Removed: []string{}, Restarted: []string{}}
+	r.chainsMu.RLock()
+	current := make(map[string]*ChainRuntime, len(r.chains))
+	for nId, chainRuntime := range r.chains {
+		current[nId] = chainRuntime
+	}
+	r.chainsMu.RUnlock()
+
+	next := make(map[string]*Chain, len(chains))
+	for _, chain := range chains {
+		next[chain.NID()] = chain
+	}
+
+	for nId, chainRuntime := range current {
+		chain, ok := next[nId]
+		if ok && chain.ChainProvider == chainRuntime.Provider {
+			continue
+		}
+		r.log.Info("stopping chain", zap.String("nid", nId))
+		r.stopChain(chainRuntime)
+		r.chainsMu.Lock()
+		delete(r.chains, nId)
+		r.chainsMu.Unlock()
+		// the replaced provider may hold a key rotated by the reload
+		r.closeProvider(chainRuntime)
+
+		if ok {
+			result.Restarted = append(result.Restarted, nId)
+			continue
+		}
+		r.parkMessages(chainRuntime)
+		result.Removed = append(result.Removed, nId)
+	}
+
+	for nId, chain := range next {
+		if running, ok := current[nId]; ok && running.Provider == chain.ChainProvider {
+			continue
+		}
+		chainRuntime, err := NewChainRuntime(r.log, chain)
+		if err != nil {
+			return result, err
+		}
+		if lastSavedHeight, err := r.blockStore.GetLastStoredBlock(nId); err == nil {
+			chainRuntime.LastSavedHeight = lastSavedHeight
+		}
+		if previous, ok := current[nId]; ok {
+			// messages received before the restart are still to be relayed
+			chainRuntime.MessageCache = previous.MessageCache
+		} else {
+			result.Added = append(result.Added, nId)
+		}
+
+		r.log.Info("starting chain", zap.String("nid", nId), zap.Uint64("last-saved-height", chainRuntime.LastSavedHeight))
+		r.chainsMu.Lock()
+		r.chains[nId] = chainRuntime
+		r.chainsMu.Unlock()
+		r.startChain(ctx, chainRuntime)
+	}
+
+	// an added chain may be the destination of parked messages
+	r.requeueUnroutable()
+
+	sort.Strings(result.Added)
+	sort.Strings(result.Removed)
+	sort.Strings(result.Restarted)
+	return result, nil
+}
+
+// parkMessages stores the cached messages of a removed chain so they are relayed once the chain is back
+func (r *Relayer) parkMessages(chainRuntime *ChainRuntime) {
+	for _, routeMessage := range chainRuntime.MessageCache.Messages {
+		// messages being routed are stored by the route callback if they fail
+		if routeMessage.GetIsProcessing() {
+			continue
+		}
+		r.parkMessage(routeMessage, chainRuntime)
+	}
+}
+
+// parkMessage moves the message from the cache to the db
+func (r *Relayer) parkMessage(routeMessage *types.RouteMessage, src *ChainRuntime) {
+	if err := r.messageStore.StoreMessage(routeMessage); err != nil {
+		r.log.Error("failed to store message of unknown chain", zap.Any("message-key", routeMessage.MessageKey()), zap.Error(err))
+		return
+	}
+	src.MessageCache.Remove(routeMessage.MessageKey())
+}
@@ -0,0 +1,131 @@
+package relayer
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/memdb"
+	"github.com/icon-project/centralized-relay/relayer/provider"
+	"github.com/icon-project/centralized-relay/relayer/store"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// closingProvider records whether the relayer released the provider
+type closingProvider struct {
+	provider.ChainProvider
+	closed atomic.Bool
+}
+
+func (p *closingProvider) Close() error {
+	p.closed.Store(true)
+	return nil
+}
+
+func newClosingChain(t *testing.T, log *zap.Logger, nId, dst string) *Chain {
+	t.Helper()
+	p, err := GetMockChainProvider(log, time.Second, nId, dst, 10, 10)
+	require.NoError(t, err)
+	return NewChain(log, &closingProvider{ChainProvider: p}, false)
+}
+
+func isClosed(chain *Chain) bool {
+	return chain.ChainProvider.(*closingProvider).closed.Load()
+}
+
+func TestReload(t *testing.T) {
+	log := zap.NewNop()
+	newChain := func(nId, dst string) *Chain {
+		return newClosingChain(t, log, nId, dst)
+	}
+	mock1, mock2 := newChain("mock-1", "mock-2"), newChain("mock-2", "mock-1")
+
+	rly, err := NewRelayer(log, memdb.NewMemDB(), map[string]*Chain{"mock-1": mock1, "mock-2": mock2}, true)
+	require.NoError(t, err)
+	rly.errorChan = make(chan error, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for _, chainRuntime := range rly.chainRuntimes() {
+		rly.runChain(ctx, chainRuntime)
+	}
+
+	runtime1, err := rly.FindChainRuntime("mock-1")
+	require.NoError(t, err)
+	runtime2, err := rly.FindChainRuntime("mock-2")
+	require.NoError(t, err)
+	cached := types.NewRouteMessage(&types.Message{Src: "mock-1", Dst: "mock-2", Sn: 1})
+	runtime1.MessageCache.Add(cached)
+	pending := types.NewRouteMessage(&types.Message{Src: "mock-2", Dst: "mock-1", Sn: 2})
+	runtime2.MessageCache.Add(pending)
+
+	// unchanged config keeps the chains running
+	result, err := rly.Reload(ctx, map[string]*Chain{"mock-1": mock1, "mock-2": mock2})
+	require.NoError(t, err)
+	assert.False(t, result.Changed())
+	assert.False(t, isClosed(mock1))
+
+	// mock-1 is restarted with a new provider, mock-2 is removed and mock-3 is added
+	mock3 := newChain("mock-3", "mock-1")
+	result, err = rly.Reload(ctx, map[string]*Chain{
+		"mock-1": newChain("mock-1", "mock-2"),
+		"mock-3": mock3,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ReloadResult{Added: []string{"mock-3"}, Removed: []string{"mock-2"}, Restarted: []string{"mock-1"}}, result)
+	// the keys of the replaced providers are released
+	assert.True(t, isClosed(mock1))
+	assert.True(t, isClosed(mock2))
+	assert.False(t, isClosed(mock3))
+
+	restarted, err := rly.FindChainRuntime("mock-1")
+	require.NoError(t, err)
+	assert.NotSame(t, runtime1, restarted)
+	assert.Contains(t, restarted.MessageCache.Messages, cached.MessageKey())
+	_, err = rly.FindChainRuntime("mock-2")
+	assert.Error(t, err)
+	_, err = rly.FindChainRuntime("mock-3")
+	assert.NoError(t, err)
+
+	// the messages of the removed chain are kept in the db
+	assert.Zero(t, runtime2.MessageCache.Len())
+	stored, err := rly.messageStore.GetMessages("mock-2", store.NewPagination().GetAll())
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	assert.Equal(t, pending.MessageKey(), stored[0].MessageKey())
+
+	// messages to the removed chain are parked instead of being routed
+	rly.processMessages(ctx)
+	assert.Zero(t, restarted.MessageCache.Len())
+	stored, err = rly.unroutableStore.GetMessages("mock-1", store.NewPagination().GetAll())
+	require.NoError(t, err)
+	assert.Len(t, stored, 1)
+
+	select {
+	case err := <-rly.errorChan:
+		t.Fatal(err)
+	default:
+	}
+}
+
+func TestKeyZeroing(t *testing.T) {
+	log := zap.NewNop()
+	mock1, mock2 := newClosingChain(t, log, "mock-1", "mock-2"), newClosingChain(t, log, "mock-2", "mock-1")
+	rly, err := NewRelayer(log, memdb.NewMemDB(), map[string]*Chain{"mock-1": mock1, "mock-2": mock2}, true)
+	require.NoError(t, err)
+	rly.errorChan = make(chan error, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	for _, chainRuntime := range rly.chainRuntimes() {
+		rly.runChain(ctx, chainRuntime)
+	}
+	WithKeyZeroing()(ctx, rly)
+
+	assert.False(t, isClosed(mock1))
+	cancel()
+	assert.Eventually(t, func() bool {
+		return isClosed(mock1) && isClosed(mock2)
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,114 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/icon-project/centralized-relay/relayer/provider"
+	"github.com/icon-project/centralized-relay/relayer/types"
+)
+
+// RescanOptions selects the blocks of a chain to scan again
+type RescanOptions struct {
+	Chain string `json:"chain"`
+	From  uint64 `json:"from"`
+	To    uint64 `json:"to"`
+}
+
+// RescanReport is the outcome of a rescan, Undelivered are the messages queued again
+type RescanReport struct {
+	Chain string `json:"chain"`
+	From  uint64 `json:"from"`
+	To    uint64 `json:"to"`
+	// Found counts the messages emitted in the blocks
+	Found uint64 `json:"found"`
+	// Received counts the messages already received by every destination
+	Received map[string]uint64 `json:"received"`
+	// Pending counts the messages the relayer is already relaying
+	Pending uint64 `json:"pending"`
+	// Unroutable counts the messages parked since their route is not relayed
+	Unroutable  uint64 `json:"unroutable"`
+	Quarantined uint64 `json:"quarantined"`
+	Undelivered []Gap  `json:"undelivered,omitempty"`
+}
+
+// Rescan parses the blocks From..To of a chain again and queues the messages which their
+// destination did not receive. The height stored for the listener is left as is
+func (r *Relayer) Rescan(ctx context.Context, opts RescanOptions) (*RescanReport, error) {
+	src, err := r.FindChainRuntime(opts.Chain)
+	if err != nil {
+		return nil, err
+	}
+	rescanner, ok := src.Provider.(provider.Rescanner)
+	if !ok {
+		return nil, fmt.Errorf("chain type %s cannot rescan blocks", src.Provider.Type())
+	}
+	if opts.From == 0 || opts.From > opts.To {
+		return nil, fmt.Errorf("invalid block range %d-%d", opts.From, opts.To)
+	}
+	latest, err := src.Provider.QueryLatestHeight(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("latest height: %w", err)
+	}
+	if opts.To > latest {
+		return nil, fmt.Errorf("block %d is after the latest height %d", opts.To, latest)
+	}
+
+	blocks, err := rescanner.Rescan(ctx, opts.From, opts.To)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rescan %s: %w", opts.Chain, err)
+	}
+	pending, err := r.pendingSn(src)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &RescanReport{Chain: opts.Chain, From: opts.From, To: opts.To, Received: make(map[string]uint64)}
+	for _, block := range blocks {
+		r.quarantineMessages(block.Quarantined)
+		report.Quarantined += uint64(len(block.Quarantined))
+		for _, m := range block.Messages {
+			report.Found++
+			if err := r.rescanMessage(ctx, src, m, pending, report); err != nil {
+				return report, err
+			}
+		}
+	}
+	return report, nil
+}
+
+func (r *Relayer) rescanMessage(ctx context.Context, src *ChainRuntime, m *types.Message, pending map[uint64]bool, report *RescanReport) error {
+	if pending[m.Sn] {
+		report.Pending++
+		return nil
+	}
+	if reason := r.unroutableReason(m.Src, m.Dst); reason != "" {
+		r.parkUnroutable(types.NewRouteMessage(m), src, reason)
+		report.Unroutable++
+		return nil
+	}
+	dst, err := r.FindChainRuntime(m.Dst)
+	if err != nil {
+		return err
+	}
+	received, err := dst.Provider.MessageReceived(ctx, m.MessageKey())
+	if err != nil {
+		return fmt.Errorf("receipt of sn %d on %s: %w", m.Sn, m.Dst, err)
+	}
+	if received {
+		report.Received[m.Dst]++
+		return nil
+	}
+
+	gap := Gap{Src: m.Src, Dst: m.Dst, Sn: m.Sn, Height: m.MessageHeight}
+	if err := r.queueMessage(src, m); err != nil {
+		gap.Error = fmt.Sprintf("failed to queue the message: %v", err)
+	} else {
+		gap.Requeued = true
+		pending[m.Sn] = true
+		// the rescan verified the message, an earlier quarantine of it is stale
+		r.unquarantine(m.MessageKey())
+	}
+	report.Undelivered = append(report.Undelivered, gap)
+	return nil
+}
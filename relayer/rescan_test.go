@@ -0,0 +1,64 @@
+package relayer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/icon-project/centralized-relay/relayer/memdb"
+	"github.com/icon-project/centralized-relay/relayer/store"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRescan(t *testing.T) {
+	log := zap.NewNop()
+	src := newSnProvider(t, log, "mock-1", "mock-2", 50)
+	dst := newSnProvider(t, log, "mock-2", "mock-1", 50)
+	src.emit("mock-2", 5)  // 1 before the range
+	src.emit("mock-2", 10) // 2 received
+	src.emit("mock-2", 12) // 3 missed by the listener
+	src.emit("other", 12)  // 4 to a chain which is not configured
+	src.emit("mock-2", 20) // 5 being relayed
+	src.emit("mock-2", 45) // 6 after the range
+	dst.received[2] = true
+
+	rly, err := NewRelayer(log, memdb.NewMemDB(), map[string]*Chain{
+		"mock-1": NewChain(log, src, true),
+		"mock-2": NewChain(log, dst, true),
+	}, true)
+	require.NoError(t, err)
+	runtime, err := rly.FindChainRuntime("mock-1")
+	require.NoError(t, err)
+	require.NoError(t, rly.messageStore.StoreMessage(types.NewRouteMessage(src.messages[4])))
+	ctx := context.Background()
+
+	report, err := rly.Rescan(ctx, RescanOptions{Chain: "mock-1", From: 10, To: 30})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), report.Found)
+	assert.Equal(t, map[string]uint64{"mock-2": 1}, report.Received)
+	assert.Equal(t, uint64(1), report.Pending)
+	assert.Equal(t, uint64(1), report.Unroutable)
+	_, err = rly.unroutableStore.GetMessage(src.messages[3].MessageKey())
+	assert.NoError(t, err)
+	assert.Equal(t, []Gap{{Src: "mock-1", Dst: "mock-2", Sn: 3, Height: 12, Requeued: true}}, report.Undelivered)
+	_, err = rly.messageStore.GetMessage(src.messages[2].MessageKey())
+	assert.NoError(t, err)
+	assert.Contains(t, runtime.MessageCache.Messages, src.messages[2].MessageKey())
+
+	// the height of the listener is not moved
+	_, err = rly.blockStore.GetLastStoredBlock("mock-1")
+	assert.True(t, store.IsNotFound(err), err)
+
+	// queued messages are pending on the next rescan
+	report, err = rly.Rescan(ctx, RescanOptions{Chain: "mock-1", From: 10, To: 30})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), report.Pending)
+	assert.Empty(t, report.Undelivered)
+
+	_, err = rly.Rescan(ctx, RescanOptions{Chain: "mock-1", From: 30, To: 10})
+	assert.Error(t, err)
+	_, err = rly.Rescan(ctx, RescanOptions{Chain: "mock-1", From: 10, To: 60})
+	assert.Error(t, err)
+}
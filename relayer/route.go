@@ -0,0 +1,83 @@
+package relayer
+
+import (
+	"context"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/types"
+)
+
+// Route is the direction messages are relayed in, from the chain Src to the chain Dst
+type Route struct {
+	Src string `json:"src" yaml:"src"`
+	Dst string `json:"dst" yaml:"dst"`
+	// TxRetry is the number of failed deliveries after which a message of the route is stored
+	// to be flushed again, types.DefaultTxRetry when zero
+	TxRetry uint64 `json:"txRetry,omitempty" yaml:"tx-retry,omitempty"`
+	// FlushInterval is how often the stored messages of the route are flushed, the flush interval
+	// of the relayer when zero
+	FlushInterval time.Duration `json:"flushInterval,omitempty" yaml:"flush-interval,omitempty"`
+}
+
+// WithRoutes relays only the messages of the routes with their policies, the messages of the other
+// routes are kept in the db. All the routes between the chains are relayed without it
+func WithRoutes(routes ...Route) Option {
+	return func(ctx context.Context, r *Relayer) {
+		r.routes = make(map[routeKey]bool, len(routes))
+		for _, route := range routes {
+			r.routes[routeKey{route.Src, route.Dst}] = true
+		}
+		WithRoutePolicies(routes...)(ctx, r)
+	}
+}
+
+// WithRoutePolicies applies the policies of the routes without limiting the relayed routes
+func WithRoutePolicies(routes ...Route) Option {
+	return func(ctx context.Context, r *Relayer) {
+		r.policies = make(map[routeKey]Route, len(routes))
+		for _, route := range routes {
+			r.policies[routeKey{route.Src, route.Dst}] = route
+		}
+	}
+}
+
+func (r *Relayer) routeActive(src, dst string) bool {
+	return r.routes == nil || r.routes[routeKey{src, dst}]
+}
+
+// txRetry is the number of failed deliveries after which a message of the route is stored
+func (r *Relayer) txRetry(src, dst string) uint64 {
+	if retry := r.policies[routeKey{src, dst}].TxRetry; retry > 0 {
+		return retry
+	}
+	return uint64(types.DefaultTxRetry)
+}
+
+// flushTick is the interval of the flush timer, the shortest flush interval of the relayer and the routes
+func (r *Relayer) flushTick() time.Duration {
+	tick := r.flushInterval
+	for _, policy := range r.policies {
+		if policy.FlushInterval > 0 && policy.FlushInterval < tick {
+			tick = policy.FlushInterval
+		}
+	}
+	return tick
+}
+
+// flushDue is true when the stored messages of the route are flushed at now, flushMu is held
+func (r *Relayer) flushDue(key routeKey, now time.Time) bool {
+	interval := r.policies[key].FlushInterval
+	if interval == 0 {
+		interval = r.flushInterval
+	}
+	last, ok := r.lastFlush[key]
+	// the timer may fire slightly early, so the route is due within half a tick
+	return !ok || now.Sub(last)+r.flushTick()/2 >= interval
+}
+
+// forgetFlushes makes the stored messages of every route due for the next flush
+func (r *Relayer) forgetFlushes() {
+	r.flushMu.Lock()
+	defer r.flushMu.Unlock()
+	clear(r.lastFlush)
+}
@@ -0,0 +1,101 @@
+package relayer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/memdb"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestWithRoutes(t *testing.T) {
+	log := zap.NewNop()
+	chains := make(map[string]*Chain)
+	for _, nIds := range [][2]string{{"mock-1", "mock-2"}, {"mock-2", "mock-1"}, {"mock-3", "mock-1"}} {
+		p, err := GetMockChainProvider(log, time.Second, nIds[0], nIds[1], 10, 10)
+		require.NoError(t, err)
+		chains[nIds[0]] = NewChain(log, p, false)
+	}
+	rly, err := NewRelayer(log, memdb.NewMemDB(), chains, true)
+	require.NoError(t, err)
+	ctx := context.Background()
+	assert.True(t, rly.routeActive("mock-1", "mock-3"))
+
+	WithRoutes(Route{Src: "mock-1", Dst: "mock-2"})(ctx, rly)
+	assert.True(t, rly.routeActive("mock-1", "mock-2"))
+	assert.False(t, rly.routeActive("mock-2", "mock-1"))
+
+	src, err := rly.FindChainRuntime("mock-1")
+	require.NoError(t, err)
+	active := types.NewRouteMessage(&types.Message{Src: "mock-1", Dst: "mock-2", Sn: 1})
+	inactive := types.NewRouteMessage(&types.Message{Src: "mock-1", Dst: "mock-3", Sn: 2})
+	src.MessageCache.Add(active)
+	src.MessageCache.Add(inactive)
+	rly.processMessages(ctx)
+
+	// the message of the active route is relayed and the one of the route left to another relayer is
+	// parked in the db, both leave the cache
+	require.Eventually(t, func() bool {
+		return src.MessageCache.Len() == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	_, err = rly.unroutableStore.GetMessage(inactive.MessageKey())
+	assert.NoError(t, err)
+	for _, m := range src.MessageCache.Snapshot() {
+		assert.NotEqual(t, inactive.MessageKey(), m.MessageKey())
+	}
+	_, err = rly.messageStore.GetMessage(active.MessageKey())
+	assert.Error(t, err)
+}
+
+func TestRoutePolicies(t *testing.T) {
+	log := zap.NewNop()
+	chains := make(map[string]*Chain)
+	for _, nIds := range [][2]string{{"mock-1", "mock-2"}, {"mock-2", "mock-1"}} {
+		p, err := GetMockChainProvider(log, time.Second, nIds[0], nIds[1], 10, 10)
+		require.NoError(t, err)
+		chains[nIds[0]] = NewChain(log, p, false)
+	}
+	rly, err := NewRelayer(log, memdb.NewMemDB(), chains, true)
+	require.NoError(t, err)
+	rly.flushInterval = time.Hour
+	ctx := context.Background()
+
+	WithRoutePolicies(Route{Src: "mock-1", Dst: "mock-2", TxRetry: 5, FlushInterval: time.Minute})(ctx, rly)
+	assert.True(t, rly.routeActive("mock-2", "mock-1"))
+	assert.Equal(t, uint64(5), rly.txRetry("mock-1", "mock-2"))
+	assert.Equal(t, uint64(types.DefaultTxRetry), rly.txRetry("mock-2", "mock-1"))
+	assert.Equal(t, time.Minute, rly.flushTick())
+
+	// the route with a policy is flushed every minute, the other one every hour
+	m1 := types.NewRouteMessage(&types.Message{Src: "mock-1", Dst: "mock-2", Sn: 1})
+	m2 := types.NewRouteMessage(&types.Message{Src: "mock-2", Dst: "mock-1", Sn: 2})
+	require.NoError(t, rly.messageStore.StoreMessage(m1))
+	require.NoError(t, rly.messageStore.StoreMessage(m2))
+	src1, err := rly.FindChainRuntime("mock-1")
+	require.NoError(t, err)
+	src2, err := rly.FindChainRuntime("mock-2")
+	require.NoError(t, err)
+	rly.flushMessages(ctx)
+	assert.Equal(t, uint64(1), src1.MessageCache.Len())
+	assert.Equal(t, uint64(1), src2.MessageCache.Len())
+
+	src1.MessageCache.Remove(m1.MessageKey())
+	src2.MessageCache.Remove(m2.MessageKey())
+	for key := range rly.lastFlush {
+		rly.lastFlush[key] = rly.lastFlush[key].Add(-time.Minute)
+	}
+	rly.flushMessages(ctx)
+	assert.Equal(t, uint64(1), src1.MessageCache.Len())
+	assert.Zero(t, src2.MessageCache.Len())
+
+	// a new leader flushes every route at once
+	src1.MessageCache.Remove(m1.MessageKey())
+	rly.forgetFlushes()
+	rly.flushMessages(ctx)
+	assert.Equal(t, uint64(1), src1.MessageCache.Len())
+	assert.Equal(t, uint64(1), src2.MessageCache.Len())
+}
@@ -0,0 +1,63 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ErrClosed is returned by the signers used after Close
+var ErrClosed = errors.New("signer is closed")
+
+// Local signs with a private key held in memory, it is used for the keystores of the chain config
+type Local struct {
+	// mu guards key, which is zeroed on Close
+	mu        sync.RWMutex
+	key       *ecdsa.PrivateKey
+	publicKey []byte
+}
+
+var _ Signer = (*Local)(nil)
+
+// NewLocal returns a signer of the 32 bytes private key, privateKey can be zeroed once it returns
+func NewLocal(privateKey []byte) (*Local, error) {
+	key, err := crypto.ToECDSA(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Local{key: key, publicKey: crypto.FromECDSAPub(&key.PublicKey)}, nil
+}
+
+func (l *Local) PublicKey() []byte {
+	return l.publicKey
+}
+
+func (l *Local) Sign(_ context.Context, digest []byte) ([]byte, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.key == nil {
+		return nil, ErrClosed
+	}
+	return crypto.Sign(digest, l.key)
+}
+
+// Close zeroes the private key in memory, the signer cannot sign afterwards
+func (l *Local) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.key != nil {
+		Zero(l.key.D.Bits())
+		l.key = nil
+	}
+	return nil
+}
+
+// Zero overwrites the key material with zeros
+func Zero[T ~byte | ~uint](b []T) {
+	for i := range b {
+		b[i] = 0
+	}
+}
@@ -0,0 +1,146 @@
+//go:build cgo
+
+package signer
+
+import (
+	"context"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/miekg/pkcs11"
+)
+
+// PKCS11 signs with a key pair of an hsm token, the private key never leaves the token
+type PKCS11 struct {
+	ctx        *pkcs11.Ctx
+	session    pkcs11.SessionHandle
+	privateKey pkcs11.ObjectHandle
+	publicKey  []byte
+	// mu serializes the use of the session
+	mu sync.Mutex
+}
+
+var _ Signer = (*PKCS11)(nil)
+
+// NewPKCS11 loads the module and logs in the token of the config
+func NewPKCS11(cfg *PKCS11Config) (Signer, error) {
+	ctx := pkcs11.New(cfg.Module)
+	if ctx == nil {
+		return nil, fmt.Errorf("failed to load pkcs11 module %s", cfg.Module)
+	}
+	if err := ctx.Initialize(); err != nil && !isPKCS11Error(err, pkcs11.CKR_CRYPTOKI_ALREADY_INITIALIZED) {
+		ctx.Destroy()
+		return nil, fmt.Errorf("failed to initialize pkcs11 module: %w", err)
+	}
+	p := &PKCS11{ctx: ctx}
+	if err := p.open(cfg); err != nil {
+		p.Close()
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *PKCS11) open(cfg *PKCS11Config) error {
+	slot, err := p.findSlot(cfg.TokenLabel)
+	if err != nil {
+		return err
+	}
+	if p.session, err = p.ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION); err != nil {
+		return fmt.Errorf("failed to open pkcs11 session: %w", err)
+	}
+	if err := p.ctx.Login(p.session, pkcs11.CKU_USER, cfg.PIN); err != nil && !isPKCS11Error(err, pkcs11.CKR_USER_ALREADY_LOGGED_IN) {
+		return fmt.Errorf("failed to login to token %s: %w", cfg.TokenLabel, err)
+	}
+
+	publicKey, err := p.findObject(pkcs11.CKO_PUBLIC_KEY, cfg.KeyLabel)
+	if err != nil {
+		return err
+	}
+	if p.privateKey, err = p.findObject(pkcs11.CKO_PRIVATE_KEY, cfg.KeyLabel); err != nil {
+		return err
+	}
+	attrs, err := p.ctx.GetAttributeValue(p.session, publicKey, []*pkcs11.Attribute{pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil)})
+	if err != nil {
+		return fmt.Errorf("failed to read public key %s: %w", cfg.KeyLabel, err)
+	}
+	// the point is a der encoded octet string
+	if _, err := asn1.Unmarshal(attrs[0].Value, &p.publicKey); err != nil {
+		return fmt.Errorf("invalid public key %s: %w", cfg.KeyLabel, err)
+	}
+	if len(p.publicKey) != 65 || p.publicKey[0] != 4 {
+		return fmt.Errorf("public key %s is not an uncompressed secp256k1 point", cfg.KeyLabel)
+	}
+	return nil
+}
+
+func (p *PKCS11) findSlot(tokenLabel string) (uint, error) {
+	slots, err := p.ctx.GetSlotList(true)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list pkcs11 slots: %w", err)
+	}
+	for _, slot := range slots {
+		info, err := p.ctx.GetTokenInfo(slot)
+		if err == nil && info.Label == tokenLabel {
+			return slot, nil
+		}
+	}
+	return 0, fmt.Errorf("pkcs11 token %s not found", tokenLabel)
+}
+
+func (p *PKCS11) findObject(class uint, label string) (pkcs11.ObjectHandle, error) {
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, class),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+	}
+	if err := p.ctx.FindObjectsInit(p.session, template); err != nil {
+		return 0, err
+	}
+	objects, _, err := p.ctx.FindObjects(p.session, 1)
+	if finalErr := p.ctx.FindObjectsFinal(p.session); err == nil {
+		err = finalErr
+	}
+	if err != nil {
+		return 0, err
+	}
+	if len(objects) == 0 {
+		return 0, fmt.Errorf("pkcs11 key %s not found", label)
+	}
+	return objects[0], nil
+}
+
+func (p *PKCS11) PublicKey() []byte {
+	return p.publicKey
+}
+
+func (p *PKCS11) Sign(_ context.Context, digest []byte) ([]byte, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := p.ctx.SignInit(p.session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_ECDSA, nil)}, p.privateKey); err != nil {
+		return nil, fmt.Errorf("pkcs11 sign: %w", err)
+	}
+	rs, err := p.ctx.Sign(p.session, digest)
+	if err != nil {
+		return nil, fmt.Errorf("pkcs11 sign: %w", err)
+	}
+	return recoverable(p.publicKey, digest, rs)
+}
+
+// Close logs out and unloads the module
+func (p *PKCS11) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.session != 0 {
+		p.ctx.Logout(p.session)
+		p.ctx.CloseSession(p.session)
+	}
+	p.ctx.Finalize()
+	p.ctx.Destroy()
+	return nil
+}
+
+func isPKCS11Error(err error, code uint) bool {
+	var pErr pkcs11.Error
+	return errors.As(err, &pErr) && uint(pErr) == code
+}
@@ -0,0 +1,59 @@
+package signer
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/icon-project/centralized-relay/relayer/provider"
+)
+
+// PKCS11Config is the secp256k1 key pair of an hsm token, the keys are found by label
+type PKCS11Config struct {
+	// Module is the path of the pkcs11 library, e.g. /usr/lib/softhsm/libsofthsm2.so
+	Module     string `json:"module" yaml:"module"`
+	TokenLabel string `json:"token-label" yaml:"token-label"`
+	KeyLabel   string `json:"key-label" yaml:"key-label"`
+	PIN        string `json:"pin" yaml:"pin"`
+}
+
+func (c *PKCS11Config) Validate() error {
+	var errs provider.ConfigErrors
+	if c.Module == "" {
+		errs.Add("signer module is required")
+	}
+	if c.TokenLabel == "" {
+		errs.Add("signer token-label is required")
+	}
+	if c.KeyLabel == "" {
+		errs.Add("signer key-label is required")
+	}
+	return errs.Err()
+}
+
+var (
+	secp256k1N     = crypto.S256().Params().N
+	secp256k1HalfN = new(big.Int).Rsh(secp256k1N, 1)
+)
+
+// recoverable turns the [R || S] signature of an hsm into the [R || S || V] signature of the chains,
+// S is made canonical and V is found by recovering the public key
+func recoverable(publicKey, digest, rs []byte) ([]byte, error) {
+	if len(rs) != 64 {
+		return nil, fmt.Errorf("invalid signature length %d", len(rs))
+	}
+	s := new(big.Int).SetBytes(rs[32:])
+	if s.Cmp(secp256k1HalfN) > 0 {
+		s.Sub(secp256k1N, s)
+	}
+	sig := make([]byte, crypto.SignatureLength)
+	copy(sig, rs[:32])
+	s.FillBytes(sig[32:64])
+	for v := byte(0); v < 2; v++ {
+		sig[64] = v
+		if Verify(publicKey, digest, sig) == nil {
+			return sig, nil
+		}
+	}
+	return nil, fmt.Errorf("signature is not made by the signer key")
+}
@@ -0,0 +1,10 @@
+//go:build !cgo
+
+package signer
+
+import "errors"
+
+// NewPKCS11 is not available as the pkcs11 module is loaded with cgo
+func NewPKCS11(*PKCS11Config) (Signer, error) {
+	return nil, errors.New("pkcs11 signer requires a build with cgo enabled")
+}
@@ -0,0 +1,90 @@
+//go:build cgo
+
+package signer
+
+import (
+	"context"
+	"encoding/asn1"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/miekg/pkcs11"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// secp256k1OID is the named curve of the generated key pairs
+var secp256k1OID = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+
+// TestPKCS11 runs against a SoftHSM token, e.g.
+//
+//	softhsm2-util --init-token --free --label relayer --pin 1234 --so-pin 1234
+//	PKCS11_MODULE=/usr/lib/softhsm/libsofthsm2.so PKCS11_TOKEN=relayer PKCS11_PIN=1234 go test ./relayer/signer/ -run PKCS11
+func TestPKCS11(t *testing.T) {
+	module := os.Getenv("PKCS11_MODULE")
+	if module == "" {
+		t.Skip("PKCS11_MODULE is not set")
+	}
+	cfg := &PKCS11Config{
+		Module:     module,
+		TokenLabel: os.Getenv("PKCS11_TOKEN"),
+		KeyLabel:   "relayer-test",
+		PIN:        os.Getenv("PKCS11_PIN"),
+	}
+	generateKeyPair(t, cfg)
+
+	s, err := NewPKCS11(cfg)
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		digest := crypto.Keccak256([]byte{byte(i)})
+		sig, err := s.Sign(context.Background(), digest)
+		require.NoError(t, err)
+		assert.NoError(t, Verify(s.PublicKey(), digest, sig))
+		// chains reject the high s form
+		assert.True(t, crypto.ValidateSignatureValues(sig[64], bigInt(sig[:32]), bigInt(sig[32:64]), true))
+	}
+}
+
+// generateKeyPair creates a session key pair, it is kept until the test ends
+func generateKeyPair(t *testing.T, cfg *PKCS11Config) {
+	t.Helper()
+	ctx := pkcs11.New(cfg.Module)
+	require.NotNil(t, ctx)
+	require.NoError(t, ctx.Initialize())
+	p := &PKCS11{ctx: ctx}
+	t.Cleanup(func() { p.Close() })
+
+	slot, err := p.findSlot(cfg.TokenLabel)
+	require.NoError(t, err)
+	p.session, err = ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
+	require.NoError(t, err)
+	require.NoError(t, ctx.Login(p.session, pkcs11.CKU_USER, cfg.PIN))
+	session := p.session
+
+	params, err := asn1.Marshal(secp256k1OID)
+	require.NoError(t, err)
+	_, _, err = ctx.GenerateKeyPair(session,
+		[]*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_EC_KEY_PAIR_GEN, nil)},
+		[]*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_TOKEN, false),
+			pkcs11.NewAttribute(pkcs11.CKA_VERIFY, true),
+			pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, params),
+			pkcs11.NewAttribute(pkcs11.CKA_LABEL, cfg.KeyLabel),
+		},
+		[]*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_TOKEN, false),
+			pkcs11.NewAttribute(pkcs11.CKA_SIGN, true),
+			pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, true),
+			pkcs11.NewAttribute(pkcs11.CKA_EXTRACTABLE, false),
+			pkcs11.NewAttribute(pkcs11.CKA_LABEL, cfg.KeyLabel),
+		},
+	)
+	require.NoError(t, err)
+}
+
+func bigInt(b []byte) *big.Int {
+	return new(big.Int).SetBytes(b)
+}
@@ -0,0 +1,161 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/icon-project/centralized-relay/relayer/provider"
+)
+
+const defaultRemoteTimeout = 10 * time.Second
+
+// RemoteConfig is the signing service holding the key, see Server for the api
+type RemoteConfig struct {
+	URL   string `json:"url" yaml:"url"`
+	KeyID string `json:"key-id" yaml:"key-id"`
+	// Token is sent as bearer token when set
+	Token   string `json:"token,omitempty" yaml:"token,omitempty"`
+	Timeout string `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+}
+
+func (c *RemoteConfig) Validate() error {
+	var errs provider.ConfigErrors
+	if c.URL == "" {
+		errs.Add("signer url is required")
+	} else {
+		errs.AddErr(provider.ValidateURL("signer url", c.URL, "http", "https"))
+	}
+	if c.KeyID == "" {
+		errs.Add("signer key-id is required")
+	}
+	if c.Timeout != "" {
+		if _, err := time.ParseDuration(c.Timeout); err != nil {
+			errs.Add("signer timeout: %w", err)
+		}
+	}
+	return errs.Err()
+}
+
+// Remote signs with a key of a signing service
+type Remote struct {
+	cfg       *RemoteConfig
+	client    *http.Client
+	publicKey []byte
+}
+
+var _ Signer = (*Remote)(nil)
+
+// keyResponse is the body of GET /v1/keys/{id}
+type keyResponse struct {
+	ID        string `json:"id"`
+	PublicKey string `json:"public-key"`
+}
+
+// signRequest is the body of POST /v1/keys/{id}/sign
+type signRequest struct {
+	Digest string `json:"digest"`
+}
+
+type signResponse struct {
+	Signature string `json:"signature"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// NewRemote returns the signer of the key of the signing service, the public key is fetched once
+func NewRemote(ctx context.Context, cfg *RemoteConfig) (*Remote, error) {
+	timeout := defaultRemoteTimeout
+	if cfg.Timeout != "" {
+		var err error
+		if timeout, err = time.ParseDuration(cfg.Timeout); err != nil {
+			return nil, err
+		}
+	}
+	r := &Remote{cfg: cfg, client: &http.Client{Timeout: timeout}}
+
+	var key keyResponse
+	if err := r.do(ctx, http.MethodGet, "", nil, &key); err != nil {
+		return nil, fmt.Errorf("failed to get the public key of %s: %w", cfg.KeyID, err)
+	}
+	publicKey, err := hex.DecodeString(key.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key of %s: %w", cfg.KeyID, err)
+	}
+	// the chains derive their addresses from the 65 bytes uncompressed key
+	if _, err := crypto.UnmarshalPubkey(publicKey); err != nil {
+		return nil, fmt.Errorf("public key of %s is not an uncompressed secp256k1 point: %w", cfg.KeyID, err)
+	}
+	r.publicKey = publicKey
+	return r, nil
+}
+
+func (r *Remote) PublicKey() []byte {
+	return r.publicKey
+}
+
+func (r *Remote) Sign(ctx context.Context, digest []byte) ([]byte, error) {
+	var resp signResponse
+	if err := r.do(ctx, http.MethodPost, "/sign", signRequest{Digest: hex.EncodeToString(digest)}, &resp); err != nil {
+		return nil, fmt.Errorf("remote signer: %w", err)
+	}
+	sig, err := hex.DecodeString(resp.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer returned an invalid signature: %w", err)
+	}
+	if err := Verify(r.publicKey, digest, sig); err != nil {
+		return nil, fmt.Errorf("remote signer returned an invalid signature: %w", err)
+	}
+	return sig, nil
+}
+
+func (r *Remote) do(ctx context.Context, method, path string, body, result any) error {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	u, err := url.JoinPath(r.cfg.URL, "v1", "keys", r.cfg.KeyID)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if r.cfg.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.cfg.Token)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiErr errorResponse
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Error != "" {
+			return errors.New(apiErr.Error)
+		}
+		return fmt.Errorf("signer returned %s", resp.Status)
+	}
+	return json.Unmarshal(data, result)
+}
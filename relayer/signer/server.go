@@ -0,0 +1,87 @@
+package signer
+
+import (
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// Server is the reference signing service of the remote signer, it holds the keys by id.
+//
+//	GET  /v1/keys/{id}       -> {"id": "...", "public-key": "<hex>"}
+//	POST /v1/keys/{id}/sign  {"digest": "<hex>"} -> {"signature": "<hex>"}
+//
+// Errors are returned as {"error": "..."}, requests need the bearer token when one is set
+type Server struct {
+	log   *zap.Logger
+	keys  map[string]Signer
+	token string
+}
+
+// NewServer returns the signing service of the keys
+func NewServer(log *zap.Logger, keys map[string]Signer, token string) *Server {
+	return &Server{log: log, keys: keys, token: token}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.token != "" {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			writeError(w, http.StatusUnauthorized, "invalid token")
+			return
+		}
+	}
+
+	path, ok := strings.CutPrefix(r.URL.Path, "/v1/keys/")
+	if !ok {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	id, action, _ := strings.Cut(path, "/")
+	key, ok := s.keys[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "key "+id+" not found")
+		return
+	}
+
+	switch {
+	case action == "" && r.Method == http.MethodGet:
+		writeJSON(w, keyResponse{ID: id, PublicKey: hex.EncodeToString(key.PublicKey())})
+	case action == "sign" && r.Method == http.MethodPost:
+		var req signRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		digest, err := hex.DecodeString(req.Digest)
+		if err != nil || len(digest) != 32 {
+			writeError(w, http.StatusBadRequest, "digest must be 32 hex encoded bytes")
+			return
+		}
+		sig, err := key.Sign(r.Context(), digest)
+		if err != nil {
+			s.log.Error("failed to sign", zap.String("key-id", id), zap.Error(err))
+			writeError(w, http.StatusInternalServerError, "failed to sign")
+			return
+		}
+		s.log.Info("signed digest", zap.String("key-id", id), zap.String("digest", req.Digest), zap.String("remote", r.RemoteAddr))
+		writeJSON(w, signResponse{Signature: hex.EncodeToString(sig)})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse{Error: msg})
+}
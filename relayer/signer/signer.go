@@ -0,0 +1,91 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/icon-project/centralized-relay/relayer/provider"
+)
+
+// signer types of the config, the keystore of the chain config is used when no signer is configured
+const (
+	TypeKeystore = "keystore"
+	TypeRemote   = "remote"
+	TypePKCS11   = "pkcs11"
+)
+
+// Signer signs with a secp256k1 key it holds, signers other than the local one never expose the key
+type Signer interface {
+	// PublicKey returns the 65 bytes uncompressed public key
+	PublicKey() []byte
+	// Sign returns the 65 bytes [R || S || V] signature of the 32 bytes digest, V is 0 or 1
+	Sign(ctx context.Context, digest []byte) ([]byte, error)
+}
+
+// Config selects the signer of a chain
+type Config struct {
+	Type   string        `json:"type" yaml:"type"`
+	Remote *RemoteConfig `json:"remote,omitempty" yaml:"remote,omitempty"`
+	PKCS11 *PKCS11Config `json:"pkcs11,omitempty" yaml:"pkcs11,omitempty"`
+}
+
+// IsKeystore is true when the chain signs with its keystore
+func (c *Config) IsKeystore() bool {
+	return c == nil || c.Type == "" || c.Type == TypeKeystore
+}
+
+// Validate reports every problem of the signer config
+func (c *Config) Validate() error {
+	var errs provider.ConfigErrors
+	switch {
+	case c.IsKeystore():
+	case c.Type == TypeRemote:
+		if c.Remote == nil {
+			errs.Add("signer remote is required with type %s", TypeRemote)
+			break
+		}
+		errs.AddErr(c.Remote.Validate())
+	case c.Type == TypePKCS11:
+		if c.PKCS11 == nil {
+			errs.Add("signer pkcs11 is required with type %s", TypePKCS11)
+			break
+		}
+		errs.AddErr(c.PKCS11.Validate())
+	default:
+		errs.Add("signer type %q must be one of %s, %s or %s", c.Type, TypeKeystore, TypeRemote, TypePKCS11)
+	}
+	return errs.Err()
+}
+
+// New returns the remote or pkcs11 signer of the config, keystore signers are built by the chains
+// as the keystore format is chain specific
+func New(ctx context.Context, c *Config) (Signer, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	switch c.Type {
+	case TypeRemote:
+		return NewRemote(ctx, c.Remote)
+	case TypePKCS11:
+		return NewPKCS11(c.PKCS11)
+	default:
+		return nil, fmt.Errorf("signer type %q is built from the keystore of the chain", c.Type)
+	}
+}
+
+// Verify checks that sig is a valid signature of digest by the signer,
+// the signers check the signatures returned by an hsm or a remote service with it
+func Verify(publicKey, digest, sig []byte) error {
+	if len(sig) != crypto.SignatureLength {
+		return fmt.Errorf("invalid signature length %d", len(sig))
+	}
+	recovered, err := crypto.Ecrecover(digest, sig)
+	if err != nil {
+		return err
+	}
+	if string(recovered) != string(publicKey) {
+		return fmt.Errorf("signature is not made by the signer key")
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const testPrivateKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+func newTestLocal(t *testing.T) *Local {
+	t.Helper()
+	key, err := crypto.HexToECDSA(testPrivateKey)
+	require.NoError(t, err)
+	local, err := NewLocal(crypto.FromECDSA(key))
+	require.NoError(t, err)
+	return local
+}
+
+func TestLocal(t *testing.T) {
+	local := newTestLocal(t)
+	digest := crypto.Keccak256([]byte("message"))
+
+	sig, err := local.Sign(context.Background(), digest)
+	require.NoError(t, err)
+	assert.NoError(t, Verify(local.PublicKey(), digest, sig))
+	assert.Equal(t, "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", crypto.PubkeyToAddress(*mustPubkey(t, local.PublicKey())).Hex())
+
+	assert.Error(t, Verify(local.PublicKey(), crypto.Keccak256([]byte("other")), sig))
+
+	require.NoError(t, local.Close())
+	_, err = local.Sign(context.Background(), digest)
+	assert.ErrorIs(t, err, ErrClosed)
+}
+
+func TestRemote(t *testing.T) {
+	local := newTestLocal(t)
+	srv := httptest.NewServer(NewServer(zap.NewNop(), map[string]Signer{"relayer": local}, "token"))
+	defer srv.Close()
+	ctx := context.Background()
+
+	remote, err := NewRemote(ctx, &RemoteConfig{URL: srv.URL, KeyID: "relayer", Token: "token"})
+	require.NoError(t, err)
+	assert.Equal(t, local.PublicKey(), remote.PublicKey())
+
+	digest := crypto.Keccak256([]byte("message"))
+	sig, err := remote.Sign(ctx, digest)
+	require.NoError(t, err)
+	assert.NoError(t, Verify(local.PublicKey(), digest, sig))
+
+	_, err = NewRemote(ctx, &RemoteConfig{URL: srv.URL, KeyID: "relayer", Token: "wrong"})
+	assert.ErrorContains(t, err, "invalid token")
+	_, err = NewRemote(ctx, &RemoteConfig{URL: srv.URL, KeyID: "unknown", Token: "token"})
+	assert.ErrorContains(t, err, "key unknown not found")
+	_, err = remote.Sign(ctx, []byte("short"))
+	assert.ErrorContains(t, err, "digest must be 32")
+
+	// a key which is not an uncompressed secp256k1 point is rejected before any signature
+	compressed := crypto.CompressPubkey(mustPubkey(t, local.PublicKey()))
+	invalid := append([]byte{4}, make([]byte, 64)...)
+	for _, publicKey := range [][]byte{compressed, invalid, local.PublicKey()[:64]} {
+		srv := httptest.NewServer(NewServer(zap.NewNop(), map[string]Signer{"relayer": &publicKeySigner{local, publicKey}}, ""))
+		_, err = NewRemote(ctx, &RemoteConfig{URL: srv.URL, KeyID: "relayer"})
+		assert.ErrorContains(t, err, "not an uncompressed secp256k1 point")
+		srv.Close()
+	}
+}
+
+// publicKeySigner reports publicKey as its public key
+type publicKeySigner struct {
+	Signer
+	publicKey []byte
+}
+
+func (s *publicKeySigner) PublicKey() []byte {
+	return s.publicKey
+}
+
+func TestRecoverable(t *testing.T) {
+	local := newTestLocal(t)
+	digest := crypto.Keccak256([]byte("message"))
+	sig, err := local.Sign(context.Background(), digest)
+	require.NoError(t, err)
+
+	rs := append([]byte{}, sig[:64]...)
+	recovered, err := recoverable(local.PublicKey(), digest, rs)
+	require.NoError(t, err)
+	assert.Equal(t, sig, recovered)
+
+	// hsms may return the high s form of the signature
+	highS := new(big.Int).Sub(secp256k1N, new(big.Int).SetBytes(sig[32:64]))
+	highS.FillBytes(rs[32:])
+	recovered, err = recoverable(local.PublicKey(), digest, rs)
+	require.NoError(t, err)
+	assert.Equal(t, sig, recovered)
+
+	_, err = recoverable(local.PublicKey(), crypto.Keccak256([]byte("other")), sig[:64])
+	assert.Error(t, err)
+}
+
+func TestConfigValidate(t *testing.T) {
+	assert.NoError(t, (*Config)(nil).Validate())
+	assert.NoError(t, (&Config{Type: TypeKeystore}).Validate())
+	assert.Error(t, (&Config{Type: TypeRemote}).Validate())
+	assert.Error(t, (&Config{Type: "vault"}).Validate())
+	assert.Error(t, (&Config{Type: TypeRemote, Remote: &RemoteConfig{URL: "ftp://signer"}}).Validate())
+	assert.NoError(t, (&Config{Type: TypeRemote, Remote: &RemoteConfig{URL: "http://127.0.0.1:5184", KeyID: "relayer"}}).Validate())
+	assert.Error(t, (&Config{Type: TypePKCS11, PKCS11: &PKCS11Config{Module: "/usr/lib/softhsm/libsofthsm2.so"}}).Validate())
+}
+
+func mustPubkey(t *testing.T, publicKey []byte) *ecdsa.PublicKey {
+	t.Helper()
+	pub, err := crypto.UnmarshalPubkey(publicKey)
+	require.NoError(t, err)
+	return pub
+}
@@ -0,0 +1,126 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/icon-project/centralized-relay/relayer/wallet"
+)
+
+// statusQueryTimeout bounds every query made for the chain status
+const statusQueryTimeout = 15 * time.Second
+
+// ChainStatus is the live diagnostic of a chain
+type ChainStatus struct {
+	NID               string `json:"nid"`
+	ChainName         string `json:"chainName"`
+	Type              string `json:"type"`
+	LatestHeight      uint64 `json:"latestHeight"`
+	LastStoredHeight  uint64 `json:"lastStoredHeight"`
+	Lag               uint64 `json:"lag"`
+	WalletAddress     string `json:"walletAddress,omitempty"`
+	Balance           string `json:"balance,omitempty"`
+	ContractReachable bool   `json:"contractReachable"`
+	// Wallets is the accounting of the wallet pool of the chain
+	Wallets []wallet.Stats `json:"wallets,omitempty"`
+	Errors  []string       `json:"errors,omitempty"`
+}
+
+// Healthy is true when all the diagnostics succeeded
+func (s *ChainStatus) Healthy() bool {
+	return len(s.Errors) == 0
+}
+
+func (s *ChainStatus) addError(what string, err error) {
+	s.Errors = append(s.Errors, fmt.Sprintf("%s: %v", what, err))
+}
+
+// ChainStatus queries the status of the given chains, all the chains when none is given
+func (r *Relayer) ChainStatus(ctx context.Context, nIds ...string) ([]*ChainStatus, error) {
+	var runtimes []*ChainRuntime
+	if len(nIds) == 0 {
+		runtimes = r.chainRuntimes()
+	}
+	for _, nId := range nIds {
+		chainRuntime, err := r.FindChainRuntime(nId)
+		if err != nil {
+			return nil, err
+		}
+		runtimes = append(runtimes, chainRuntime)
+	}
+
+	statuses := make([]*ChainStatus, len(runtimes))
+	var wg sync.WaitGroup
+	for i, chainRuntime := range runtimes {
+		wg.Add(1)
+		go func(i int, chainRuntime *ChainRuntime) {
+			defer wg.Done()
+			statuses[i] = r.chainStatus(ctx, chainRuntime)
+		}(i, chainRuntime)
+	}
+	wg.Wait()
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].NID < statuses[j].NID
+	})
+	return statuses, nil
+}
+
+func (r *Relayer) chainStatus(ctx context.Context, chainRuntime *ChainRuntime) *ChainStatus {
+	p := chainRuntime.Provider
+	status := &ChainStatus{
+		NID:       p.NID(),
+		ChainName: p.ChainName(),
+		Type:      p.Type(),
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, statusQueryTimeout)
+	defer cancel()
+
+	latest, err := p.QueryLatestHeight(ctx)
+	if err != nil {
+		status.addError("latest height", err)
+	}
+	status.LatestHeight = latest
+
+	stored, err := r.blockStore.GetLastStoredBlock(p.NID())
+	if err != nil {
+		status.addError("last stored block", err)
+	}
+	status.LastStoredHeight = stored
+	if latest > stored && stored > 0 {
+		status.Lag = latest - stored
+	}
+
+	if status.WalletAddress, err = p.GetWalletAddress(); err != nil {
+		status.addError("wallet address", err)
+	} else if coin, err := p.QueryBalance(ctx, status.WalletAddress); err != nil {
+		status.addError("balance", err)
+	} else if coin != nil {
+		status.Balance = coin.String()
+	}
+
+	if pool, ok := p.(walletPool); ok && pool.Wallets() != nil {
+		for _, w := range pool.Wallets().Wallets() {
+			stats := w.Stats()
+			if coin, err := p.QueryBalance(ctx, w.Address); err != nil {
+				status.addError("balance of "+w.Address, err)
+			} else if coin != nil {
+				stats.Balance = coin.String()
+			}
+			status.Wallets = append(status.Wallets, stats)
+		}
+	}
+
+	// a receipt lookup is a read only contract call, it succeeds only if the contract is reachable
+	if _, err := p.MessageReceived(ctx, types.MessageKey{Src: p.NID(), Sn: 0}); err != nil {
+		status.addError("contract", err)
+	} else {
+		status.ContractReachable = true
+	}
+	return status
+}
@@ -0,0 +1,71 @@
+package relayer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/chains/mockchain"
+	"github.com/icon-project/centralized-relay/relayer/memdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestChainStatus(t *testing.T) {
+	t.Parallel()
+	log := zap.NewNop()
+	mock1, err := GetMockChainProvider(log, time.Second, "mock-1", "mock-2", 10, 20)
+	require.NoError(t, err)
+	mock1.(*mockchain.MockProvider).PCfg.Balance = big.NewInt(42)
+	mock2, err := GetMockChainProvider(log, time.Second, "mock-2", "mock-1", 20, 10)
+	require.NoError(t, err)
+
+	rly, err := NewRelayer(log, memdb.NewMemDB(), map[string]*Chain{
+		"mock-1": NewChain(log, mock1, true),
+		"mock-2": NewChain(log, mock2, true),
+	}, true)
+	require.NoError(t, err)
+	require.NoError(t, rly.blockStore.StoreBlock(4, "mock-1"))
+
+	statuses, err := rly.ChainStatus(context.Background())
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+
+	s := statuses[0]
+	assert.Equal(t, "mock-1", s.NID)
+	assert.Equal(t, uint64(10), s.LatestHeight)
+	assert.Equal(t, uint64(4), s.LastStoredHeight)
+	assert.Equal(t, uint64(6), s.Lag)
+	assert.Equal(t, "mock-wallet", s.WalletAddress)
+	assert.Equal(t, "42mock", s.Balance)
+	assert.True(t, s.ContractReachable)
+	assert.True(t, s.Healthy())
+
+	// nothing stored yet for mock-2
+	assert.False(t, statuses[1].Healthy())
+
+	_, err = rly.ChainStatus(context.Background(), "unknown")
+	assert.Error(t, err)
+}
+
+func TestChainStatusWallets(t *testing.T) {
+	t.Parallel()
+	log := zap.NewNop()
+	p := newPooledProvider(t, log, map[string]int64{"wallet-1": 7, "wallet-2": 3}, "wallet-1", "wallet-2")
+	rly, err := NewRelayer(log, memdb.NewMemDB(), map[string]*Chain{"mock-1": NewChain(log, p, true)}, true)
+	require.NoError(t, err)
+
+	p.pool.Acquire().Release(nil)
+	p.pool.Acquire()
+	statuses, err := rly.ChainStatus(context.Background(), "mock-1")
+	require.NoError(t, err)
+	wallets := statuses[0].Wallets
+	require.Len(t, wallets, 2)
+	assert.Equal(t, "wallet-1", wallets[0].Address)
+	assert.Equal(t, uint64(1), wallets[0].Sent)
+	assert.Equal(t, "7mock", wallets[0].Balance)
+	assert.Equal(t, int64(1), wallets[1].InFlight)
+	assert.Equal(t, "3mock", wallets[1].Balance)
+}
@@ -5,6 +5,7 @@ package store
 
 import (
 	"encoding/json"
+	"strings"
 )
 
 type BlockStore struct {
@@ -42,6 +43,23 @@ func (bs *BlockStore) GetLastStoredBlock(nId string) (uint64, error) {
 	return height, json.Unmarshal(v, &height)
 }
 
+// GetLastStoredBlocks returns the latest known block of every chain keyed by nId
+func (bs *BlockStore) GetLastStoredBlocks() (map[string]uint64, error) {
+	keyPrefix := string(GetKey([]string{bs.prefix, ""}))
+	iter := bs.db.NewIterator([]byte(keyPrefix))
+	defer iter.Release()
+
+	heights := make(map[string]uint64)
+	for iter.Next() {
+		var height uint64
+		if err := bs.Decode(iter.Value(), &height); err != nil {
+			return nil, err
+		}
+		heights[strings.TrimPrefix(string(iter.Key()), keyPrefix)] = height
+	}
+	return heights, iter.Error()
+}
+
 func (ms *BlockStore) Encode(d interface{}) ([]byte, error) {
 	return json.Marshal(d)
 }
@@ -3,24 +3,13 @@ package store
 import (
 	"testing"
 
-	"github.com/icon-project/centralized-relay/relayer/lvldb"
+	"github.com/icon-project/centralized-relay/relayer/memdb"
 	"github.com/stretchr/testify/assert"
 )
 
-var (
-	testDBName = "./testdb"
-)
-
 func TestBlockStore(t *testing.T) {
-
-	testdb, err := lvldb.NewLvlDB(testDBName, false)
-	if err != nil {
-		assert.Fail(t, "error while creating test db ", err)
-	}
-
-	if err := testdb.ClearStore(); err != nil {
-		assert.Fail(t, "failed to clear db ", err)
-	}
+	t.Parallel()
+	testdb := memdb.NewMemDB()
 
 	prefix := "block"
 	nId := "icon"
@@ -46,9 +35,4 @@ func TestBlockStore(t *testing.T) {
 	getHeight, err = blockStore.GetLastStoredBlock(nId)
 	assert.NoError(t, err)
 	assert.Equal(t, replaceHeight, getHeight)
-
-	if err := testdb.ClearStore(); err != nil {
-		assert.Fail(t, "failed to clear db ", err)
-	}
-
 }
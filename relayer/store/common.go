@@ -1,7 +1,17 @@
 package store
 
-import "strings"
+import (
+	"errors"
+	"strings"
+
+	"github.com/syndtr/goleveldb/leveldb"
+)
 
 func GetKey(keys []string) []byte {
 	return []byte(strings.Join(keys, "-"))
 }
+
+// IsNotFound is true when the key is not in the store
+func IsNotFound(err error) bool {
+	return errors.Is(err, leveldb.ErrNotFound) || errors.Is(err, ErrNotFound)
+}
@@ -0,0 +1,342 @@
+package store
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+)
+
+const EncryptionKeySize = 32
+
+var (
+	// encryptionCheckKey is stored unhashed so the key can be verified before any data is read
+	encryptionCheckKey   = GetKey([]string{prefixMeta, "encryption-check"})
+	encryptionCheckValue = []byte("centralized-relay")
+
+	ErrWrongEncryptionKey = errors.New("database encryption key does not match")
+	ErrPlaintextDatabase  = errors.New("database contains unencrypted data, rotate the key to encrypt it")
+)
+
+var _ Store = (*EncryptedStore)(nil)
+
+// EncryptedStore seals every value of the underlying store with AES-GCM.
+// when keys are hashed every "-" separated key segment is replaced by its HMAC
+// so prefix iteration keeps working for prefixes of whole segments, in hash order.
+// The original key is kept inside the sealed value
+type EncryptedStore struct {
+	db       Store
+	aead     cipher.AEAD
+	hashKeys bool
+	hmacKey  []byte
+}
+
+// NewEncryptedStore wraps db, the key must be EncryptionKeySize bytes long
+func NewEncryptedStore(db Store, key []byte, hashKeys bool) (*EncryptedStore, error) {
+	s, err := newEncryptedStore(db, key, hashKeys)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.verify(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func newEncryptedStore(db Store, key []byte, hashKeys bool) (*EncryptedStore, error) {
+	if len(key) != EncryptionKeySize {
+		return nil, fmt.Errorf("encryption key must be %d bytes, got %d", EncryptionKeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("centralized-relay/key-hash"))
+
+	return &EncryptedStore{
+		db:       db,
+		aead:     aead,
+		hashKeys: hashKeys,
+		hmacKey:  mac.Sum(nil),
+	}, nil
+}
+
+// verify checks the key against the stored check value,
+// an empty database is initialized with the check value
+func (s *EncryptedStore) verify() error {
+	v, err := s.db.GetByKey(encryptionCheckKey)
+	if err != nil {
+		if !IsNotFound(err) {
+			return err
+		}
+		if !isEmpty(s.db) {
+			return ErrPlaintextDatabase
+		}
+		return s.writeCheck()
+	}
+	plain, err := s.open(encryptionCheckKey, v)
+	if err != nil || !bytes.Equal(plain, encryptionCheckValue) {
+		return ErrWrongEncryptionKey
+	}
+	return nil
+}
+
+func (s *EncryptedStore) writeCheck() error {
+	return s.db.SetByKey(encryptionCheckKey, s.seal(encryptionCheckKey, encryptionCheckValue))
+}
+
+func (s *EncryptedStore) GetByKey(key []byte) ([]byte, error) {
+	storedKey := s.storedKey(key)
+	v, err := s.db.GetByKey(storedKey)
+	if err != nil {
+		return nil, err
+	}
+	_, value, err := s.decode(storedKey, v)
+	return value, err
+}
+
+func (s *EncryptedStore) SetByKey(key []byte, value []byte) error {
+	storedKey := s.storedKey(key)
+	return s.db.SetByKey(storedKey, s.encode(storedKey, key, value))
+}
+
+func (s *EncryptedStore) DeleteByKey(key []byte) error {
+	return s.db.DeleteByKey(s.storedKey(key))
+}
+
+func (s *EncryptedStore) ClearStore() error {
+	if err := s.db.ClearStore(); err != nil {
+		return err
+	}
+	return s.writeCheck()
+}
+
+func (s *EncryptedStore) NewIterator(prefix []byte) iterator.Iterator {
+	var storedPrefix []byte
+	if prefix != nil {
+		storedPrefix = s.storedKey(prefix)
+	}
+	return &encryptedIterator{Iterator: s.db.NewIterator(storedPrefix), s: s}
+}
+
+// storedKey returns the key used in the underlying store
+func (s *EncryptedStore) storedKey(key []byte) []byte {
+	if !s.hashKeys {
+		return key
+	}
+	segments := strings.Split(string(key), "-")
+	for i, segment := range segments {
+		if segment == "" {
+			continue
+		}
+		mac := hmac.New(sha256.New, s.hmacKey)
+		mac.Write([]byte(segment))
+		segments[i] = hex.EncodeToString(mac.Sum(nil))
+	}
+	return []byte(strings.Join(segments, "-"))
+}
+
+// encode seals the original key together with the value
+func (s *EncryptedStore) encode(storedKey, key, value []byte) []byte {
+	plain := binary.AppendUvarint(nil, uint64(len(key)))
+	plain = append(plain, key...)
+	plain = append(plain, value...)
+	return s.seal(storedKey, plain)
+}
+
+func (s *EncryptedStore) decode(storedKey, data []byte) ([]byte, []byte, error) {
+	plain, err := s.open(storedKey, data)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyLen, n := binary.Uvarint(plain)
+	if n <= 0 || uint64(len(plain)-n) < keyLen {
+		return nil, nil, fmt.Errorf("invalid encrypted value of key %x", storedKey)
+	}
+	key := plain[n : n+int(keyLen)]
+	return key, plain[n+int(keyLen):], nil
+}
+
+func (s *EncryptedStore) seal(storedKey, plain []byte) []byte {
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		panic(err)
+	}
+	return s.aead.Seal(nonce, nonce, plain, storedKey)
+}
+
+func (s *EncryptedStore) open(storedKey, data []byte) ([]byte, error) {
+	nonceSize := s.aead.NonceSize()
+	if len(data) < nonceSize {
+		return nil, fmt.Errorf("invalid encrypted value of key %x", storedKey)
+	}
+	plain, err := s.aead.Open(nil, data[:nonceSize], data[nonceSize:], storedKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt value of key %x: %w", storedKey, err)
+	}
+	return plain, nil
+}
+
+type encryptedIterator struct {
+	iterator.Iterator
+	s          *EncryptedStore
+	key, value []byte
+	err        error
+}
+
+func (it *encryptedIterator) First() bool {
+	return it.skip(it.Iterator.First(), it.Iterator.Next)
+}
+
+func (it *encryptedIterator) Last() bool {
+	return it.skip(it.Iterator.Last(), it.Iterator.Prev)
+}
+
+func (it *encryptedIterator) Seek(key []byte) bool {
+	return it.skip(it.Iterator.Seek(it.s.storedKey(key)), it.Iterator.Next)
+}
+
+func (it *encryptedIterator) Next() bool {
+	return it.skip(it.Iterator.Next(), it.Iterator.Next)
+}
+
+func (it *encryptedIterator) Prev() bool {
+	return it.skip(it.Iterator.Prev(), it.Iterator.Prev)
+}
+
+// skip moves over the encryption check entry and decodes the current entry
+func (it *encryptedIterator) skip(ok bool, move func() bool) bool {
+	it.key, it.value = nil, nil
+	for ok && bytes.Equal(it.Iterator.Key(), encryptionCheckKey) {
+		ok = move()
+	}
+	if !ok || it.err != nil {
+		return false
+	}
+	it.key, it.value, it.err = it.s.decode(it.Iterator.Key(), it.Iterator.Value())
+	return it.err == nil
+}
+
+func (it *encryptedIterator) Key() []byte {
+	return it.key
+}
+
+func (it *encryptedIterator) Value() []byte {
+	return it.value
+}
+
+func (it *encryptedIterator) Error() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.Iterator.Error()
+}
+
+// RotateEncryptionKey re-encrypts all the data of db under newKey, the data is rewritten in a single
+// batch so a crash leaves it under either key. oldKey of nil means the database is not encrypted yet
+func RotateEncryptionKey(db Store, oldKey []byte, oldHashKeys bool, newKey []byte, newHashKeys bool) (int, error) {
+	batcher, ok := db.(Batcher)
+	if !ok {
+		return 0, fmt.Errorf("database does not support batch writes")
+	}
+	var old *EncryptedStore
+	if oldKey != nil {
+		var err error
+		if old, err = NewEncryptedStore(db, oldKey, oldHashKeys); err != nil {
+			return 0, err
+		}
+	}
+	next, err := newEncryptedStore(db, newKey, newHashKeys)
+	if err != nil {
+		return 0, err
+	}
+
+	type entry struct {
+		storedKey, key, value []byte
+	}
+	var entries []entry
+	iter := db.NewIterator(nil)
+	for iter.Next() {
+		storedKey := append([]byte(nil), iter.Key()...)
+		if bytes.Equal(storedKey, encryptionCheckKey) {
+			continue
+		}
+		e := entry{storedKey: storedKey, key: storedKey, value: append([]byte(nil), iter.Value()...)}
+		if old != nil {
+			if e.key, e.value, err = old.decode(storedKey, e.value); err != nil {
+				iter.Release()
+				return 0, err
+			}
+		}
+		entries = append(entries, e)
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return 0, err
+	}
+
+	// the old keys are deleted first, a key stored the same way under both keys is then written again
+	batch := new(leveldb.Batch)
+	for _, e := range entries {
+		batch.Delete(e.storedKey)
+	}
+	for _, e := range entries {
+		storedKey := next.storedKey(e.key)
+		batch.Put(storedKey, next.encode(storedKey, e.key, e.value))
+	}
+	batch.Put(encryptionCheckKey, next.seal(encryptionCheckKey, encryptionCheckValue))
+	if err := batcher.WriteBatch(batch); err != nil {
+		return 0, err
+	}
+	return len(entries), nil
+}
+
+// LoadEncryptionKey reads a hex encoded key from file or from the environment variable
+func LoadEncryptionKey(file, env string) ([]byte, error) {
+	var encoded string
+	switch {
+	case file != "":
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read encryption key file: %w", err)
+		}
+		encoded = string(b)
+	case env != "":
+		encoded = os.Getenv(env)
+		if encoded == "" {
+			return nil, fmt.Errorf("encryption key environment variable %s is empty", env)
+		}
+	default:
+		return nil, nil
+	}
+	key, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(encoded), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("encryption key must be hex encoded: %w", err)
+	}
+	if len(key) != EncryptionKeySize {
+		return nil, fmt.Errorf("encryption key must be %d bytes, got %d", EncryptionKeySize, len(key))
+	}
+	return key, nil
+}
+
+func isEmpty(db Store) bool {
+	iter := db.NewIterator(nil)
+	defer iter.Release()
+	return !iter.Next()
+}
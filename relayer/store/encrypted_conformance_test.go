@@ -0,0 +1,35 @@
+package store_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/icon-project/centralized-relay/relayer/memdb"
+	"github.com/icon-project/centralized-relay/relayer/store"
+	"github.com/icon-project/centralized-relay/relayer/store/storetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncryptedStoreConformance(t *testing.T) {
+	t.Parallel()
+	key := bytes.Repeat([]byte{1}, store.EncryptionKeySize)
+	storetest.RunStoreTests(t, func(t *testing.T) store.Store {
+		db, err := store.NewEncryptedStore(memdb.NewMemDB(), key, false)
+		if err != nil {
+			assert.FailNow(t, "failed to create encrypted store", err)
+		}
+		return db
+	})
+}
+
+func TestEncryptedStoreHashedKeysConformance(t *testing.T) {
+	t.Parallel()
+	key := bytes.Repeat([]byte{1}, store.EncryptionKeySize)
+	storetest.RunStoreTests(t, func(t *testing.T) store.Store {
+		db, err := store.NewEncryptedStore(memdb.NewMemDB(), key, true)
+		if err != nil {
+			assert.FailNow(t, "failed to create encrypted store", err)
+		}
+		return db
+	}, storetest.SegmentPrefixes())
+}
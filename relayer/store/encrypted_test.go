@@ -0,0 +1,79 @@
+package store
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/icon-project/centralized-relay/relayer/memdb"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncryptedStore(t *testing.T) {
+	t.Parallel()
+	testdb := memdb.NewMemDB()
+
+	key := bytes.Repeat([]byte{1}, EncryptionKeySize)
+	newKey := bytes.Repeat([]byte{2}, EncryptionKeySize)
+
+	encrypted, err := NewEncryptedStore(testdb, key, true)
+	assert.NoError(t, err)
+
+	messageStore := NewMessageStore(encrypted, "message")
+	msg := types.NewRouteMessage(&types.Message{Src: "icon", Dst: "archway", Sn: 1, Data: []byte("secret payload")})
+	other := types.NewRouteMessage(&types.Message{Src: "archway", Dst: "icon", Sn: 1, Data: []byte("other payload")})
+	assert.NoError(t, messageStore.StoreMessage(msg))
+	assert.NoError(t, messageStore.StoreMessage(other))
+
+	t.Run("values and keys are not stored in plain", func(t *testing.T) {
+		iter := testdb.NewIterator(nil)
+		for iter.Next() {
+			assert.False(t, bytes.Contains(iter.Value(), []byte("secret payload")))
+			assert.False(t, bytes.Contains(iter.Key(), []byte("icon")))
+		}
+		iter.Release()
+	})
+
+	t.Run("prefix iteration", func(t *testing.T) {
+		msgs, err := messageStore.GetMessages("icon", NewPagination().GetAll())
+		assert.NoError(t, err)
+		assert.Equal(t, []*types.RouteMessage{msg}, msgs)
+
+		count, err := messageStore.TotalCount()
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(2), count)
+	})
+
+	t.Run("wrong key is rejected", func(t *testing.T) {
+		_, err := NewEncryptedStore(testdb, newKey, true)
+		assert.ErrorIs(t, err, ErrWrongEncryptionKey)
+	})
+
+	t.Run("rotate key", func(t *testing.T) {
+		count, err := RotateEncryptionKey(testdb, key, true, newKey, false)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		_, err = NewEncryptedStore(testdb, key, true)
+		assert.ErrorIs(t, err, ErrWrongEncryptionKey)
+
+		rotated, err := NewEncryptedStore(testdb, newKey, false)
+		assert.NoError(t, err)
+		got, err := NewMessageStore(rotated, "message").GetMessage(msg.MessageKey())
+		assert.NoError(t, err)
+		assert.Equal(t, msg, got)
+	})
+
+	t.Run("plaintext database must be rotated first", func(t *testing.T) {
+		assert.NoError(t, testdb.ClearStore())
+		assert.NoError(t, NewMessageStore(testdb, "message").StoreMessage(msg))
+		_, err := NewEncryptedStore(testdb, key, false)
+		assert.ErrorIs(t, err, ErrPlaintextDatabase)
+
+		count, err := RotateEncryptionKey(testdb, nil, false, key, false)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+		_, err = NewEncryptedStore(testdb, key, false)
+		assert.NoError(t, err)
+	})
+}
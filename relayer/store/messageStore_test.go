@@ -3,20 +3,14 @@ package store
 import (
 	"testing"
 
-	"github.com/icon-project/centralized-relay/relayer/lvldb"
+	"github.com/icon-project/centralized-relay/relayer/memdb"
 	"github.com/icon-project/centralized-relay/relayer/types"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestMessageStoreSet(t *testing.T) {
-	testdb, err := lvldb.NewLvlDB(testDBName, false)
-	if err != nil {
-		assert.Fail(t, "error while creating test db ", err)
-	}
-
-	if err := testdb.ClearStore(); err != nil {
-		assert.Fail(t, "failed to clear db ", err)
-	}
+	t.Parallel()
+	testdb := memdb.NewMemDB()
 
 	prefix := "block"
 	nId := "icon"
@@ -138,8 +132,4 @@ func TestMessageStoreSet(t *testing.T) {
 			assert.Error(t, err, "error occured when fetching messages")
 		})
 	})
-
-	if err := testdb.ClearStore(); err != nil {
-		assert.Fail(t, "failed to clear db ", err)
-	}
 }
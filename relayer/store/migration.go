@@ -0,0 +1,111 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+var (
+	// prefixMeta holds relayer metadata keys which are not part of the data stores
+	prefixMeta = "meta"
+
+	schemaVersionKey = GetKey([]string{prefixMeta, "schema-version"})
+)
+
+// Migration upgrades the stored data from Version-1 to Version
+type Migration struct {
+	Version     uint64
+	Description string
+	Up          func(db Store) error
+}
+
+type Migrator struct {
+	db         Store
+	migrations []Migration
+}
+
+// NewMigrator returns a migrator for the given migrations, ordered by version
+func NewMigrator(db Store, migrations []Migration) *Migrator {
+	ordered := make([]Migration, len(migrations))
+	copy(ordered, migrations)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Version < ordered[j].Version
+	})
+	return &Migrator{
+		db:         db,
+		migrations: ordered,
+	}
+}
+
+// Version returns the schema version recorded in the database,
+// a database without version marker is at version 0
+func (m *Migrator) Version() (uint64, error) {
+	v, err := m.db.GetByKey(schemaVersionKey)
+	if err != nil {
+		if IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	var version uint64
+	return version, json.Unmarshal(v, &version)
+}
+
+// LatestVersion returns the version the database will be at after all migrations are applied
+func (m *Migrator) LatestVersion() uint64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+func (m *Migrator) setVersion(version uint64) error {
+	v, err := json.Marshal(version)
+	if err != nil {
+		return err
+	}
+	return m.db.SetByKey(schemaVersionKey, v)
+}
+
+// Pending returns the migrations which are not applied yet
+func (m *Migrator) Pending() ([]Migration, error) {
+	current, err := m.Version()
+	if err != nil {
+		return nil, err
+	}
+	if latest := m.LatestVersion(); current > latest {
+		return nil, fmt.Errorf("database schema version %d is newer than supported version %d", current, latest)
+	}
+
+	var pending []Migration
+	for _, migration := range m.migrations {
+		if migration.Version > current {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+// Migrate applies all the pending migrations in order and returns the applied ones.
+// version is recorded after each migration so a failure can be resumed later
+func (m *Migrator) Migrate() ([]Migration, error) {
+	pending, err := m.Pending()
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make([]Migration, 0, len(pending))
+	for _, migration := range pending {
+		if migration.Up != nil {
+			if err := migration.Up(m.db); err != nil {
+				return applied, fmt.Errorf("migration %d (%s) failed: %w", migration.Version, migration.Description, err)
+			}
+		}
+		if err := m.setVersion(migration.Version); err != nil {
+			return applied, fmt.Errorf("failed to record schema version %d: %w", migration.Version, err)
+		}
+		applied = append(applied, migration)
+	}
+	return applied, nil
+}
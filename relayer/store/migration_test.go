@@ -0,0 +1,76 @@
+package store
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/icon-project/centralized-relay/relayer/memdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrator(t *testing.T) {
+	t.Parallel()
+	testdb := memdb.NewMemDB()
+
+	var applied []uint64
+	up := func(version uint64) func(Store) error {
+		return func(Store) error {
+			applied = append(applied, version)
+			return nil
+		}
+	}
+
+	migrator := NewMigrator(testdb, []Migration{
+		{Version: 2, Description: "second", Up: up(2)},
+		{Version: 1, Description: "first", Up: up(1)},
+	})
+
+	t.Run("empty database starts at version 0", func(t *testing.T) {
+		version, err := migrator.Version()
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(0), version)
+		assert.Equal(t, uint64(2), migrator.LatestVersion())
+
+		pending, err := migrator.Pending()
+		assert.NoError(t, err)
+		assert.Len(t, pending, 2)
+	})
+
+	t.Run("migrations are applied in order", func(t *testing.T) {
+		migrated, err := migrator.Migrate()
+		assert.NoError(t, err)
+		assert.Len(t, migrated, 2)
+		assert.Equal(t, []uint64{1, 2}, applied)
+
+		version, err := migrator.Version()
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(2), version)
+	})
+
+	t.Run("applied migrations are not run again", func(t *testing.T) {
+		migrated, err := migrator.Migrate()
+		assert.NoError(t, err)
+		assert.Len(t, migrated, 0)
+		assert.Equal(t, []uint64{1, 2}, applied)
+	})
+
+	t.Run("failed migration keeps the last applied version", func(t *testing.T) {
+		failing := NewMigrator(testdb, []Migration{
+			{Version: 1, Description: "first", Up: up(1)},
+			{Version: 2, Description: "second", Up: up(2)},
+			{Version: 3, Description: "third", Up: func(Store) error { return errors.New("boom") }},
+		})
+		_, err := failing.Migrate()
+		assert.Error(t, err)
+
+		version, err := failing.Version()
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(2), version)
+	})
+
+	t.Run("newer database version is rejected", func(t *testing.T) {
+		older := NewMigrator(testdb, []Migration{{Version: 1, Description: "first"}})
+		_, err := older.Migrate()
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,110 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/icon-project/centralized-relay/relayer/types"
+)
+
+// PauseStore holds the pauses of chains and routes
+type PauseStore struct {
+	db     Store
+	prefix string
+}
+
+func NewPauseStore(db Store, prefix string) *PauseStore {
+	return &PauseStore{
+		db:     db,
+		prefix: prefix,
+	}
+}
+
+// keySegment escapes the dashes of a nid, they separate the segments of the key
+var keySegment = strings.NewReplacer("%", "%25", "-", "%2D")
+
+func (ps *PauseStore) GetKey(src, dst string) []byte {
+	return GetKey([]string{ps.prefix, keySegment.Replace(src), keySegment.Replace(dst)})
+}
+
+// StorePause stores the pause replacing the one of the same chain or route
+func (ps *PauseStore) StorePause(pause *types.Pause) error {
+	if pause == nil || pause.Src == "" {
+		return fmt.Errorf("error while storing pause: src cannot be empty")
+	}
+	b, err := json.Marshal(pause)
+	if err != nil {
+		return err
+	}
+	return ps.db.SetByKey(ps.GetKey(pause.Src, pause.Dst), b)
+}
+
+// GetPause returns the pause of the chain, or of the route when dst is set
+func (ps *PauseStore) GetPause(src, dst string) (*types.Pause, error) {
+	v, err := ps.db.GetByKey(ps.GetKey(src, dst))
+	if err != nil {
+		return nil, err
+	}
+	pause := new(types.Pause)
+	if err := json.Unmarshal(v, pause); err != nil {
+		return nil, err
+	}
+	return pause, nil
+}
+
+func (ps *PauseStore) DeletePause(src, dst string) error {
+	return ps.db.DeleteByKey(ps.GetKey(src, dst))
+}
+
+// Rekey stores the pauses again under their current key, the keys of the pauses stored before
+// the nids were escaped collide for nids with a dash
+func (ps *PauseStore) Rekey() error {
+	iter := ps.db.NewIterator(GetKey([]string{ps.prefix, ""}))
+	type entry struct {
+		key   []byte
+		pause types.Pause
+	}
+	var entries []entry
+	for iter.Next() {
+		e := entry{key: append([]byte(nil), iter.Key()...)}
+		if err := json.Unmarshal(iter.Value(), &e.pause); err != nil {
+			iter.Release()
+			return err
+		}
+		entries = append(entries, e)
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if bytes.Equal(e.key, ps.GetKey(e.pause.Src, e.pause.Dst)) {
+			continue
+		}
+		if err := ps.db.DeleteByKey(e.key); err != nil {
+			return err
+		}
+		if err := ps.StorePause(&e.pause); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetPauses returns all the stored pauses
+func (ps *PauseStore) GetPauses() ([]*types.Pause, error) {
+	iter := ps.db.NewIterator(GetKey([]string{ps.prefix, ""}))
+	defer iter.Release()
+
+	var pauses []*types.Pause
+	for iter.Next() {
+		var pause types.Pause
+		if err := json.Unmarshal(iter.Value(), &pause); err != nil {
+			return nil, err
+		}
+		pauses = append(pauses, &pause)
+	}
+	return pauses, iter.Error()
+}
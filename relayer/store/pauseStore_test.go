@@ -0,0 +1,67 @@
+package store
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/icon-project/centralized-relay/relayer/memdb"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPauseStore(t *testing.T) {
+	t.Parallel()
+	pauseStore := NewPauseStore(memdb.NewMemDB(), "pause")
+	assert.Equal(t, []byte("pause-icon-"), pauseStore.GetKey("icon", ""))
+
+	expiry := time.Now().Add(time.Hour).UTC().Round(time.Second)
+	chainPause := &types.Pause{Src: "icon", Reason: "contract upgrade", CreatedAt: time.Now().UTC().Round(time.Second)}
+	routePause := &types.Pause{Src: "icon", Dst: "evm", ExpiresAt: &expiry}
+	require.NoError(t, pauseStore.StorePause(chainPause))
+	require.NoError(t, pauseStore.StorePause(routePause))
+	assert.Error(t, pauseStore.StorePause(&types.Pause{Dst: "evm"}))
+
+	pauses, err := pauseStore.GetPauses()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*types.Pause{chainPause, routePause}, pauses)
+	pause, err := pauseStore.GetPause("icon", "evm")
+	require.NoError(t, err)
+	assert.Equal(t, routePause, pause)
+	_, err = pauseStore.GetPause("evm", "")
+	assert.True(t, IsNotFound(err))
+
+	require.NoError(t, pauseStore.DeletePause("icon", ""))
+	pauses, err = pauseStore.GetPauses()
+	require.NoError(t, err)
+	assert.Equal(t, []*types.Pause{routePause}, pauses)
+
+	// the dashes of the nids do not make routes collide
+	first, second := &types.Pause{Src: "0x1-eth", Dst: "icon"}, &types.Pause{Src: "0x1", Dst: "eth-icon"}
+	assert.Equal(t, []byte("pause-0x1%2Deth-icon"), pauseStore.GetKey(first.Src, first.Dst))
+	require.NoError(t, pauseStore.StorePause(first))
+	require.NoError(t, pauseStore.StorePause(second))
+	pauses, err = pauseStore.GetPauses()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*types.Pause{routePause, first, second}, pauses)
+}
+
+func TestPauseStoreRekey(t *testing.T) {
+	t.Parallel()
+	db := memdb.NewMemDB()
+	pauseStore := NewPauseStore(db, "pause")
+	legacy := &types.Pause{Src: "0x1-eth", Dst: "icon"}
+	b, err := json.Marshal(legacy)
+	require.NoError(t, err)
+	require.NoError(t, db.SetByKey([]byte("pause-0x1-eth-icon"), b))
+	require.NoError(t, pauseStore.StorePause(&types.Pause{Src: "icon"}))
+
+	require.NoError(t, pauseStore.Rekey())
+	_, err = db.GetByKey([]byte("pause-0x1-eth-icon"))
+	assert.Error(t, err)
+	require.NoError(t, pauseStore.DeletePause(legacy.Src, legacy.Dst))
+	pauses, err := pauseStore.GetPauses()
+	require.NoError(t, err)
+	assert.Equal(t, []*types.Pause{{Src: "icon"}}, pauses)
+}
@@ -3,6 +3,7 @@ package store
 import (
 	"errors"
 
+	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/iterator"
 )
 
@@ -18,6 +19,11 @@ type Store interface {
 	DeleteByKey(key []byte) error
 }
 
+// Batcher is a store writing a batch at once, a crash leaves either all or none of its writes
+type Batcher interface {
+	WriteBatch(batch *leveldb.Batch) error
+}
+
 type KeyValueReader interface {
 	GetByKey(key []byte) ([]byte, error)
 }
@@ -0,0 +1,113 @@
+// Package storetest provides the conformance tests every store.Store backend has to pass
+package storetest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/icon-project/centralized-relay/relayer/store"
+	"github.com/stretchr/testify/assert"
+)
+
+// Option relaxes the conformance tests for a store
+type Option func(*options)
+
+type options struct {
+	segmentPrefixes bool
+}
+
+// SegmentPrefixes is for stores which only match prefixes made of whole "-" separated key segments
+// and iterate in no particular order, as an encrypted store with hashed keys does
+func SegmentPrefixes() Option {
+	return func(o *options) {
+		o.segmentPrefixes = true
+	}
+}
+
+// RunStoreTests runs the conformance tests against the stores created by newStore,
+// every subtest gets its own store
+func RunStoreTests(t *testing.T, newStore func(t *testing.T) store.Store, opts ...Option) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	t.Run("set get delete", func(t *testing.T) {
+		db := newStore(t)
+
+		_, err := db.GetByKey([]byte("missing"))
+		assert.Error(t, err)
+
+		assert.NoError(t, db.SetByKey([]byte("key"), []byte("value")))
+		v, err := db.GetByKey([]byte("key"))
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("value"), v)
+
+		assert.NoError(t, db.SetByKey([]byte("key"), []byte("replaced")))
+		v, err = db.GetByKey([]byte("key"))
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("replaced"), v)
+
+		assert.NoError(t, db.DeleteByKey([]byte("key")))
+		_, err = db.GetByKey([]byte("key"))
+		assert.Error(t, err)
+
+		// deleting a missing key is not an error
+		assert.NoError(t, db.DeleteByKey([]byte("key")))
+	})
+
+	t.Run("prefix iteration is ordered and bounded", func(t *testing.T) {
+		db := newStore(t)
+		for _, k := range []string{"message-b-2", "message-a-1", "message-b-1", "messages", "block-a", "message"} {
+			assert.NoError(t, db.SetByKey([]byte(k), []byte(k)))
+		}
+
+		assert.Empty(t, keys(t, db, "message-c"))
+		assert.Len(t, keys(t, db, ""), 6)
+		if o.segmentPrefixes {
+			assert.ElementsMatch(t, []string{"message", "message-a-1", "message-b-1", "message-b-2"}, keys(t, db, "message"))
+			assert.ElementsMatch(t, []string{"message-b-1", "message-b-2"}, keys(t, db, "message-b"))
+			return
+		}
+		assert.Equal(t, []string{"message", "message-a-1", "message-b-1", "message-b-2", "messages"}, keys(t, db, "message"))
+		assert.Equal(t, []string{"message-b-1", "message-b-2"}, keys(t, db, "message-b"))
+	})
+
+	t.Run("iterator values match keys", func(t *testing.T) {
+		db := newStore(t)
+		for i := 0; i < 20; i++ {
+			assert.NoError(t, db.SetByKey([]byte(fmt.Sprintf("k-%02d", i)), []byte(fmt.Sprintf("v-%02d", i))))
+		}
+		iter := db.NewIterator([]byte("k-"))
+		defer iter.Release()
+		count := 0
+		for iter.Next() {
+			assert.Equal(t, "v"+string(iter.Key()[1:]), string(iter.Value()))
+			count++
+		}
+		assert.NoError(t, iter.Error())
+		assert.Equal(t, 20, count)
+	})
+
+	t.Run("clear store", func(t *testing.T) {
+		db := newStore(t)
+		assert.NoError(t, db.SetByKey([]byte("a"), []byte("1")))
+		assert.NoError(t, db.SetByKey([]byte("b"), []byte("2")))
+		assert.NoError(t, db.ClearStore())
+		assert.Empty(t, keys(t, db, ""))
+
+		assert.NoError(t, db.SetByKey([]byte("c"), []byte("3")))
+		assert.Equal(t, []string{"c"}, keys(t, db, ""))
+	})
+}
+
+func keys(t *testing.T, db store.Store, prefix string) []string {
+	iter := db.NewIterator([]byte(prefix))
+	defer iter.Release()
+	var out []string
+	for iter.Next() {
+		out = append(out, string(iter.Key()))
+	}
+	assert.NoError(t, iter.Error())
+	return out
+}
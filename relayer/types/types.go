@@ -2,7 +2,10 @@ package types
 
 import (
 	"fmt"
+	"math/big"
+	"strconv"
 	"sync"
+	"time"
 )
 
 var (
@@ -14,6 +17,8 @@ var (
 type BlockInfo struct {
 	Height   uint64
 	Messages []*Message
+	// Quarantined messages failed verification and must not be relayed
+	Quarantined []*Message
 }
 
 type Message struct {
@@ -125,26 +130,70 @@ func (m *MessageCache) Add(r *RouteMessage) {
 }
 
 func (m *MessageCache) Len() uint64 {
+	m.Lock()
+	defer m.Unlock()
 	return uint64(len(m.Messages))
 }
 
+// Snapshot returns the cached messages, the cache may change while they are used
+func (m *MessageCache) Snapshot() []*RouteMessage {
+	m.Lock()
+	defer m.Unlock()
+	messages := make([]*RouteMessage, 0, len(m.Messages))
+	for _, r := range m.Messages {
+		messages = append(messages, r)
+	}
+	return messages
+}
+
 func (m *MessageCache) Remove(key MessageKey) {
 	m.Lock()
 	defer m.Unlock()
 	delete(m.Messages, key)
 }
 
+// Coin is an amount in the smallest unit of the denom,
+// Decimals is the number of decimals of the display unit
 type Coin struct {
-	Denom  string
-	Amount uint64
+	Denom    string
+	Amount   *big.Int
+	Decimals int
+}
+
+func NewCoin(denom string, amount *big.Int, decimals int) Coin {
+	return Coin{denom, amount, decimals}
 }
 
-func NewCoin(denom string, amount uint64) Coin {
-	return Coin{denom, amount}
+// Float64 returns the amount in the display unit
+func (c *Coin) Float64() float64 {
+	if c.Amount == nil {
+		return 0
+	}
+	unit := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(c.Decimals)), nil))
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(c.Amount), unit).Float64()
+	return f
 }
 
 func (c *Coin) String() string {
-	return fmt.Sprintf("%d%s", c.Amount, c.Denom)
+	return fmt.Sprintf("%s%s", strconv.FormatFloat(c.Float64(), 'f', -1, 64), c.Denom)
+}
+
+// Pause stops relaying for the Src chain, or only for the route from Src to Dst when Dst is set
+type Pause struct {
+	Src       string     `json:"src"`
+	Dst       string     `json:"dst,omitempty"`
+	Reason    string     `json:"reason,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// IsChain is true when the whole chain is paused
+func (p *Pause) IsChain() bool {
+	return p.Dst == ""
+}
+
+func (p *Pause) Expired(now time.Time) bool {
+	return p.ExpiresAt != nil && !now.Before(*p.ExpiresAt)
 }
 
 type TransactionObject struct {
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -49,3 +50,10 @@ func TestMessageCache(t *testing.T) {
 		assert.Equal(t, messageCache.Len(), uint64(0))
 	})
 }
+
+func TestCoin(t *testing.T) {
+	amount, _ := new(big.Int).SetString("1500000000000000000", 10)
+	coin := NewCoin("ICX", amount, 18)
+	assert.Equal(t, 1.5, coin.Float64())
+	assert.Equal(t, "1.5ICX", coin.String())
+}
@@ -0,0 +1,71 @@
+package relayer
+
+import (
+	"github.com/icon-project/centralized-relay/relayer/metrics"
+	"github.com/icon-project/centralized-relay/relayer/store"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"go.uber.org/zap"
+)
+
+// unroutableReason returns why this relayer does not deliver the messages of the route, empty when it does
+func (r *Relayer) unroutableReason(src, dst string) string {
+	if _, err := r.FindChainRuntime(dst); err != nil {
+		return "destination chain is not configured"
+	}
+	if !r.routeActive(src, dst) {
+		return "route is not relayed by this relayer"
+	}
+	return ""
+}
+
+// parkUnroutable moves a message which this relayer cannot deliver to the unroutable store,
+// it is queued again by requeueUnroutable once its destination is relayed
+func (r *Relayer) parkUnroutable(routeMessage *types.RouteMessage, src *ChainRuntime, reason string) {
+	key := routeMessage.MessageKey()
+	if err := r.unroutableStore.StoreMessage(routeMessage); err != nil {
+		r.log.Error("failed to store unroutable message", zap.Any("message-key", key), zap.Error(err))
+		return
+	}
+	if err := r.messageStore.DeleteMessage(key); err != nil {
+		r.log.Error("failed to delete unroutable message", zap.Any("message-key", key), zap.Error(err))
+	}
+	src.MessageCache.Remove(key)
+	metrics.MessagesUnroutable.WithLabelValues(key.Src, key.Dst).Inc()
+	r.log.Warn("message parked as unroutable",
+		zap.String("src", key.Src),
+		zap.String("dst", key.Dst),
+		zap.Uint64("sn", key.Sn),
+		zap.String("reason", reason),
+	)
+}
+
+// requeueUnroutable queues the unroutable messages which destination is relayed again
+func (r *Relayer) requeueUnroutable() {
+	messages, err := r.unroutableStore.GetMessages("", store.NewPagination().GetAll())
+	if err != nil {
+		r.log.Error("failed to get unroutable messages", zap.Error(err))
+		return
+	}
+	metrics.MessagesUnroutable.Reset()
+	var requeued int
+	for _, routeMessage := range messages {
+		key := routeMessage.MessageKey()
+		if _, err := r.FindChainRuntime(key.Dst); err != nil || !r.routeActive(key.Src, key.Dst) {
+			metrics.MessagesUnroutable.WithLabelValues(key.Src, key.Dst).Inc()
+			continue
+		}
+		// the router picks the message up from the message store with the next flush
+		if err := r.messageStore.StoreMessage(routeMessage); err != nil {
+			r.log.Error("failed to requeue unroutable message", zap.Any("message-key", key), zap.Error(err))
+			metrics.MessagesUnroutable.WithLabelValues(key.Src, key.Dst).Inc()
+			continue
+		}
+		if err := r.unroutableStore.DeleteMessage(key); err != nil {
+			r.log.Error("failed to delete requeued message", zap.Any("message-key", key), zap.Error(err))
+		}
+		requeued++
+	}
+	if requeued > 0 {
+		r.log.Info("requeued unroutable messages", zap.Int("count", requeued), zap.Int("unroutable", len(messages)-requeued))
+	}
+}
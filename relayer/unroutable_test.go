@@ -0,0 +1,54 @@
+package relayer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/icon-project/centralized-relay/relayer/memdb"
+	"github.com/icon-project/centralized-relay/relayer/store"
+	"github.com/icon-project/centralized-relay/relayer/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnroutable(t *testing.T) {
+	log := zap.NewNop()
+	mock1 := newClosingChain(t, log, "mock-1", "mock-2")
+	rly, err := NewRelayer(log, memdb.NewMemDB(), map[string]*Chain{"mock-1": mock1}, true)
+	require.NoError(t, err)
+	rly.errorChan = make(chan error, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	src, err := rly.FindChainRuntime("mock-1")
+	require.NoError(t, err)
+	message := types.NewRouteMessage(&types.Message{Src: "mock-1", Dst: "mock-2", Sn: 1})
+	require.NoError(t, rly.messageStore.StoreMessage(message))
+	src.MessageCache.Add(message)
+
+	// the message leaves the relay path instead of being flushed again every interval
+	rly.processMessages(ctx)
+	assert.NotContains(t, src.MessageCache.Messages, message.MessageKey())
+	_, err = rly.messageStore.GetMessage(message.MessageKey())
+	assert.Error(t, err)
+	_, err = rly.unroutableStore.GetMessage(message.MessageKey())
+	require.NoError(t, err)
+
+	// still unroutable without the destination
+	rly.requeueUnroutable()
+	_, err = rly.unroutableStore.GetMessage(message.MessageKey())
+	assert.NoError(t, err)
+
+	// configuring the destination queues the message again
+	_, err = rly.Reload(ctx, map[string]*Chain{
+		"mock-1": mock1,
+		"mock-2": newClosingChain(t, log, "mock-2", "mock-1"),
+	})
+	require.NoError(t, err)
+	_, err = rly.messageStore.GetMessage(message.MessageKey())
+	assert.NoError(t, err)
+	parked, err := rly.unroutableStore.GetMessages("", store.NewPagination().GetAll())
+	require.NoError(t, err)
+	assert.Empty(t, parked)
+}
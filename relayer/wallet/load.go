@@ -0,0 +1,70 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/icon-project/centralized-relay/relayer/signer"
+)
+
+// Decrypt returns the private key of a keystore of the chain, it fails when the password does not open it.
+// The private key is zeroed once the signer holds it
+type Decrypt func(data []byte, password string) ([]byte, error)
+
+// Address derives the address of the chain from the uncompressed public key of a signer
+type Address func(publicKey []byte) (string, error)
+
+// Load returns the pool of the wallets of the keys, the first key is the primary wallet of the chain.
+// A key signs with its signer, or with its keystore opened by decrypt. The keystores are decrypted
+// once and kept in memory until the pool is closed
+func Load(ctx context.Context, keys []Key, strategy Strategy, decrypt Decrypt, address Address) (*Pool, error) {
+	var wallets []*Wallet
+	for i, key := range keys {
+		w, err := load(ctx, key, decrypt, address)
+		if err != nil {
+			Close(wallets...)
+			if i == 0 {
+				return nil, fmt.Errorf("signer: %w", err)
+			}
+			return nil, fmt.Errorf("wallets[%d]: %w", i-1, err)
+		}
+		wallets = append(wallets, w)
+	}
+	pool, err := NewPool(strategy, wallets...)
+	if err != nil {
+		Close(wallets...)
+		return nil, err
+	}
+	return pool, nil
+}
+
+// load returns the wallet of the key, the keystore is used when no signer is configured
+func load(ctx context.Context, key Key, decrypt Decrypt, address Address) (*Wallet, error) {
+	s, err := newSigner(ctx, key, decrypt)
+	if err != nil {
+		return nil, err
+	}
+	addr, err := address(s.PublicKey())
+	if err != nil {
+		Close(New("", s))
+		return nil, fmt.Errorf("invalid signer public key: %w", err)
+	}
+	return New(addr, s), nil
+}
+
+func newSigner(ctx context.Context, key Key, decrypt Decrypt) (signer.Signer, error) {
+	if !key.Signer.IsKeystore() {
+		return signer.New(ctx, key.Signer)
+	}
+	data, err := os.ReadFile(key.Keystore)
+	if err != nil {
+		return nil, err
+	}
+	privateKey, err := decrypt(data, key.Password)
+	if err != nil {
+		return nil, err
+	}
+	defer signer.Zero(privateKey)
+	return signer.NewLocal(privateKey)
+}
@@ -0,0 +1,303 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/icon-project/centralized-relay/relayer/provider"
+	"github.com/icon-project/centralized-relay/relayer/signer"
+)
+
+// Strategy picks the wallet submitting the next transaction of a chain
+type Strategy string
+
+const (
+	RoundRobin    Strategy = "round-robin"
+	LeastInFlight Strategy = "least-in-flight"
+)
+
+func (s Strategy) Validate() error {
+	switch s {
+	case "", RoundRobin, LeastInFlight:
+		return nil
+	}
+	return fmt.Errorf("wallet-strategy %q must be %s or %s", s, RoundRobin, LeastInFlight)
+}
+
+// Key is an additional wallet of a chain, either a keystore or a signer
+type Key struct {
+	Keystore string `json:"keystore,omitempty" yaml:"keystore,omitempty"`
+	Password string `json:"password,omitempty" yaml:"password,omitempty"`
+	// Signer replaces the keystore with a remote or hsm signer
+	Signer *signer.Config `json:"signer,omitempty" yaml:"signer,omitempty"`
+}
+
+// Validate checks the key of wallets[i] without reading its keystore
+func (k Key) Validate(i int) error {
+	if !k.Signer.IsKeystore() {
+		if err := k.Signer.Validate(); err != nil {
+			return fmt.Errorf("wallets[%d]: %w", i, err)
+		}
+		return nil
+	}
+	if k.Keystore == "" {
+		return fmt.Errorf("wallets[%d].keystore is required", i)
+	}
+	return nil
+}
+
+// ValidateKeystore checks that the keystore of the config field opens with the password,
+// decrypt must fail when the password does not open the keystore
+func (k Key) ValidateKeystore(field string, decrypt func(data []byte, password string) error) error {
+	if !k.Signer.IsKeystore() {
+		return nil
+	}
+	data, err := provider.ReadKeystore(field, k.Keystore)
+	if err != nil {
+		return err
+	}
+	if err := decrypt(data, k.Password); err != nil {
+		return fmt.Errorf("%s %s: %w", field, k.Keystore, err)
+	}
+	return nil
+}
+
+// ValidateKeys checks the keys of a chain and its strategy without reading the keystores,
+// the first key is the keystore or signer of the chain config
+func ValidateKeys(keys []Key, strategy Strategy) error {
+	var errs provider.ConfigErrors
+	for i, key := range keys {
+		switch {
+		case i > 0:
+			errs.AddErr(key.Validate(i - 1))
+		case !key.Signer.IsKeystore():
+			errs.AddErr(key.Signer.Validate())
+		case key.Keystore == "":
+			errs.Add("keystore is required")
+		}
+	}
+	errs.AddErr(strategy.Validate())
+	return errs.Err()
+}
+
+// ValidateKeystores checks that every keystore of the keys opens with its password, each of them is decrypted
+func ValidateKeystores(keys []Key, decrypt Decrypt) error {
+	var errs provider.ConfigErrors
+	for i, key := range keys {
+		errs.AddErr(key.ValidateKeystore(KeyField(i), func(data []byte, password string) error {
+			privateKey, err := decrypt(data, password)
+			signer.Zero(privateKey)
+			return err
+		}))
+	}
+	return errs.Err()
+}
+
+// KeyField names the config field of the i-th key of a chain, the keystore comes before the wallets
+func KeyField(i int) string {
+	if i == 0 {
+		return "keystore"
+	}
+	return fmt.Sprintf("wallets[%d].keystore", i-1)
+}
+
+// Wallet is a key of a chain along with its accounting
+type Wallet struct {
+	Address string
+	Signer  signer.Signer
+
+	inFlight atomic.Int64
+	sent     atomic.Uint64
+	failed   atomic.Uint64
+	// drained is set while the balance is critical, the pool skips the wallet
+	drained atomic.Bool
+
+	// mu guards the nonce, it is only tracked by the chains with account nonces
+	mu       sync.Mutex
+	nonce    uint64
+	nonceSet bool
+}
+
+// New returns the wallet of the signer
+func New(address string, s signer.Signer) *Wallet {
+	return &Wallet{Address: address, Signer: s}
+}
+
+// NextNonce returns the nonce of the next transaction, chainNonce is the nonce known by the chain.
+// The local nonce runs ahead of the chain while transactions are pending
+func (w *Wallet) NextNonce(chainNonce uint64) uint64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.nonceSet || chainNonce > w.nonce {
+		w.nonce, w.nonceSet = chainNonce, true
+	}
+	nonce := w.nonce
+	w.nonce++
+	return nonce
+}
+
+// RewindNonce hands out nonce again when it is the last one handed out, it is only called for a
+// transaction which was not broadcast. A nonce followed by others is not rewound, the later
+// transactions may be pending already
+func (w *Wallet) RewindNonce(nonce uint64) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.nonceSet || w.nonce != nonce+1 {
+		return false
+	}
+	w.nonce = nonce
+	return true
+}
+
+// Release ends a transaction of the wallet started by Pool.Acquire
+func (w *Wallet) Release(err error) {
+	w.inFlight.Add(-1)
+	if err != nil {
+		w.failed.Add(1)
+		return
+	}
+	w.sent.Add(1)
+}
+
+// SetDrained marks the wallet as out of funds, it returns true when the state changed
+func (w *Wallet) SetDrained(drained bool) bool {
+	return w.drained.Swap(drained) != drained
+}
+
+func (w *Wallet) Drained() bool {
+	return w.drained.Load()
+}
+
+// Stats is the accounting of a wallet
+type Stats struct {
+	Address  string `json:"address"`
+	InFlight int64  `json:"inFlight"`
+	Sent     uint64 `json:"sent"`
+	Failed   uint64 `json:"failed"`
+	// Nonce is the next nonce, it is set once the wallet sent a transaction on a chain with nonces
+	Nonce   *uint64 `json:"nonce,omitempty"`
+	Drained bool    `json:"drained,omitempty"`
+	Balance string  `json:"balance,omitempty"`
+}
+
+func (w *Wallet) Stats() Stats {
+	stats := Stats{
+		Address:  w.Address,
+		InFlight: w.inFlight.Load(),
+		Sent:     w.sent.Load(),
+		Failed:   w.failed.Load(),
+		Drained:  w.drained.Load(),
+	}
+	w.mu.Lock()
+	if w.nonceSet {
+		nonce := w.nonce
+		stats.Nonce = &nonce
+	}
+	w.mu.Unlock()
+	return stats
+}
+
+// Pool spreads the transactions of a chain over its wallets, each wallet has its own nonce lane
+type Pool struct {
+	strategy Strategy
+	wallets  []*Wallet
+	// mu makes the pick and the in-flight increment atomic
+	mu   sync.Mutex
+	next int
+}
+
+// NewPool returns the pool of the wallets, the first one is the primary wallet of the chain
+func NewPool(strategy Strategy, wallets ...*Wallet) (*Pool, error) {
+	if err := strategy.Validate(); err != nil {
+		return nil, err
+	}
+	if len(wallets) == 0 {
+		return nil, errors.New("wallet pool needs at least one wallet")
+	}
+	seen := make(map[string]bool, len(wallets))
+	for _, w := range wallets {
+		if seen[w.Address] {
+			return nil, fmt.Errorf("wallet %s is given twice", w.Address)
+		}
+		seen[w.Address] = true
+	}
+	if strategy == "" {
+		strategy = RoundRobin
+	}
+	return &Pool{strategy: strategy, wallets: wallets}, nil
+}
+
+// Acquire returns the wallet of the next transaction, Release must be called once it is done.
+// Drained wallets are skipped unless all of them are drained
+func (p *Pool) Acquire() *Wallet {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var picked *Wallet
+	for i := range p.wallets {
+		w := p.wallets[(p.next+i)%len(p.wallets)]
+		if w.Drained() {
+			continue
+		}
+		if p.strategy == RoundRobin {
+			picked = w
+			break
+		}
+		// ties go to the wallet next in round robin order
+		if picked == nil || w.inFlight.Load() < picked.inFlight.Load() {
+			picked = w
+		}
+	}
+	if picked == nil {
+		picked = p.wallets[p.next%len(p.wallets)]
+	}
+	for i, w := range p.wallets {
+		if w == picked {
+			p.next = i + 1
+		}
+	}
+	picked.inFlight.Add(1)
+	return picked
+}
+
+// Primary is the wallet of the keystore or signer of the chain config
+func (p *Pool) Primary() *Wallet {
+	return p.wallets[0]
+}
+
+func (p *Pool) Wallets() []*Wallet {
+	return p.wallets
+}
+
+// Drained is true when every wallet is out of funds
+func (p *Pool) Drained() bool {
+	for _, w := range p.wallets {
+		if !w.Drained() {
+			return false
+		}
+	}
+	return true
+}
+
+// Close closes the signers holding keys in memory, the chain cannot sign afterwards.
+// A nil pool, of a chain which was not initialized, has nothing to close
+func (p *Pool) Close() error {
+	if p == nil {
+		return nil
+	}
+	return Close(p.wallets...)
+}
+
+// Close closes the signers of the wallets, the keys of the keystores are zeroed
+func Close(wallets ...*Wallet) error {
+	var errs []error
+	for _, w := range wallets {
+		if closer, ok := w.Signer.(io.Closer); ok {
+			errs = append(errs, closer.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
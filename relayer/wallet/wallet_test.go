@@ -0,0 +1,124 @@
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPool(t *testing.T) {
+	a, b, c := New("a", nil), New("b", nil), New("c", nil)
+	_, err := NewPool("random", a)
+	assert.Error(t, err)
+	_, err = NewPool(RoundRobin, a, New("a", nil))
+	assert.Error(t, err)
+
+	pool, err := NewPool("", a, b, c)
+	require.NoError(t, err)
+	assert.Equal(t, a, pool.Primary())
+	var picked []string
+	for i := 0; i < 4; i++ {
+		picked = append(picked, pool.Acquire().Address)
+	}
+	assert.Equal(t, []string{"a", "b", "c", "a"}, picked)
+
+	// drained wallets are skipped until every wallet is drained
+	b.SetDrained(true)
+	assert.Equal(t, "c", pool.Acquire().Address)
+	assert.Equal(t, "a", pool.Acquire().Address)
+	assert.False(t, pool.Drained())
+	a.SetDrained(true)
+	c.SetDrained(true)
+	assert.True(t, pool.Drained())
+	assert.NotNil(t, pool.Acquire())
+}
+
+func TestPoolLeastInFlight(t *testing.T) {
+	a, b, c := New("a", nil), New("b", nil), New("c", nil)
+	pool, err := NewPool(LeastInFlight, a, b, c)
+	require.NoError(t, err)
+
+	assert.Equal(t, a, pool.Acquire())
+	assert.Equal(t, b, pool.Acquire())
+	assert.Equal(t, c, pool.Acquire())
+	// a is done first, it has the least transactions in flight
+	a.Release(nil)
+	assert.Equal(t, a, pool.Acquire())
+	b.Release(errors.New("reverted"))
+	c.Release(nil)
+	assert.Equal(t, b, pool.Acquire())
+
+	stats := b.Stats()
+	assert.Equal(t, int64(1), stats.InFlight)
+	assert.Equal(t, uint64(1), stats.Failed)
+	assert.Equal(t, uint64(1), c.Stats().Sent)
+}
+
+func TestNextNonce(t *testing.T) {
+	w := New("a", nil)
+	assert.Nil(t, w.Stats().Nonce)
+	assert.Equal(t, uint64(5), w.NextNonce(5))
+	// the chain does not know the pending transaction yet
+	assert.Equal(t, uint64(6), w.NextNonce(5))
+	assert.Equal(t, uint64(7), *w.Stats().Nonce)
+	// the chain is ahead when a transaction was sent from elsewhere
+	assert.Equal(t, uint64(9), w.NextNonce(9))
+	// only the last nonce is handed out again
+	assert.False(t, w.RewindNonce(8))
+	assert.True(t, w.RewindNonce(9))
+	assert.Equal(t, uint64(9), w.NextNonce(8))
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	var keys []Key
+	for i, b := range []byte{1, 2} {
+		path := filepath.Join(dir, fmt.Sprintf("key-%d.json", i))
+		require.NoError(t, os.WriteFile(path, bytes.Repeat([]byte{b}, 32), 0o600))
+		keys = append(keys, Key{Keystore: path, Password: "secret"})
+	}
+	var decrypted [][]byte
+	decrypt := func(data []byte, password string) ([]byte, error) {
+		if password != "secret" {
+			return nil, errors.New("wrong password")
+		}
+		decrypted = append(decrypted, data)
+		return data, nil
+	}
+	address := func(publicKey []byte) (string, error) {
+		return hex.EncodeToString(publicKey[1:5]), nil
+	}
+
+	pool, err := Load(context.Background(), keys, RoundRobin, decrypt, address)
+	require.NoError(t, err)
+	require.Len(t, pool.Wallets(), 2)
+	assert.NotEqual(t, pool.Wallets()[0].Address, pool.Wallets()[1].Address)
+	// the signers hold a copy of the keys, the decrypted ones are zeroed
+	for _, key := range decrypted {
+		assert.Equal(t, make([]byte, 32), key)
+	}
+	require.NoError(t, pool.Close())
+	_, err = pool.Primary().Signer.Sign(context.Background(), make([]byte, 32))
+	assert.Error(t, err)
+
+	keys[1].Password = "wrong"
+	_, err = Load(context.Background(), keys, RoundRobin, decrypt, address)
+	assert.EqualError(t, err, "wallets[0]: wrong password")
+	_, err = Load(context.Background(), keys[:1], "random", decrypt, address)
+	assert.Error(t, err)
+	_, err = Load(context.Background(), keys[:1], RoundRobin, decrypt, func([]byte) (string, error) {
+		return "", errors.New("not a public key")
+	})
+	assert.EqualError(t, err, "signer: invalid signer public key: not a public key")
+
+	var nilPool *Pool
+	assert.NoError(t, nilPool.Close())
+}